@@ -61,22 +61,26 @@ func (s *userService) GetUser(id int) string {
 type emailService struct {
     server     string
     log        *zap.SugaredLogger // Changed to correct type
-    retryCount int                `di:"retry-count"`
+    RetryCount int                `di:"retry-count"` // exported so the container can inject it
 }
 
+// defaultRetryCount is used when no "retry-count" value is injected
+const defaultRetryCount = 3
+
 func NewEmailService() EmailService {
     log := logger.Get()
     log.Infow("Creating new EmailService", "server", "smtp.example.com")
     return &emailService{
-        server: "smtp.example.com",
-        log:    log,
+        server:     "smtp.example.com",
+        log:        log,
+        RetryCount: defaultRetryCount,
     }
 }
 
 func (s *emailService) PostConstruct() error {
     s.log.Info("PostConstruct: Initializing EmailService")
-    if s.retryCount == 0 {
-        s.retryCount = 3 // default retry count
+    if s.RetryCount == 0 {
+        s.RetryCount = defaultRetryCount
     }
     return nil
 }
@@ -92,11 +96,11 @@ func (s *emailService) SendEmail(to, message string) error {
         "to", to,
         "server", s.server,
         "messageLength", len(message),
-        "retryCount", s.retryCount)
+        "retryCount", s.RetryCount)
 
     // Added retry logic
     var lastError error
-    for attempt := 0; attempt < s.retryCount; attempt++ {
+    for attempt := 0; attempt < s.RetryCount; attempt++ {
         s.log.Debugw("Sending attempt",
             "attempt", attempt+1,
             "to", to)
@@ -112,7 +116,7 @@ func (s *emailService) SendEmail(to, message string) error {
     }
 
     return fmt.Errorf("failed to send email after %d attempts: %v",
-        s.retryCount, lastError)
+        s.RetryCount, lastError)
 }
 
 // ConfigService implementation with profiles
@@ -140,6 +144,15 @@ func (s *configService) PostConstruct() error {
     return nil
 }
 
+// SetProfiles receives the container's active profiles before PostConstruct, and again from
+// ResolveWithProfiles when they changed since, so the environment follows them
+func (s *configService) SetProfiles(profiles []string) {
+    s.profiles = profiles
+    if len(profiles) > 0 {
+        s.env = profiles[0]
+    }
+}
+
 func (s *configService) PreDestroy() error {
     s.log.Info("PreDestroy: Cleaning up ConfigService")
     return nil
@@ -2,6 +2,7 @@ package services
 
 import (
     "testing"
+    "di-extended/pkg/container"
     "github.com/stretchr/testify/assert"
     "github.com/stretchr/testify/require"
     "strings"
@@ -126,4 +127,30 @@ func TestConfigService_GetConfig(t *testing.T) {
     // Test result format and content
     assert.True(t, strings.HasPrefix(result, "Environment:"))
     assert.Contains(t, result, "development")
-}
\ No newline at end of file
+}
+
+func TestEmailService_RetryCountInjection(t *testing.T) {
+    di := container.NewContainer()
+    require.NoError(t, di.Register("retry-count", 5, container.Singleton))
+
+    service := NewEmailService()
+    require.NoError(t, di.InjectStruct(service))
+
+    emailSvc := service.(*emailService)
+    assert.Equal(t, 5, emailSvc.RetryCount)
+}
+
+func TestConfigService_ResolveWithProfiles(t *testing.T) {
+    di := container.NewContainer()
+    require.NoError(t, di.Register("configService", NewConfigService(), container.Singleton))
+
+    // Registered before any profile was active, so the env is still the default
+    resolved, err := di.Resolve("configService")
+    require.NoError(t, err)
+    assert.Equal(t, "Environment: development", resolved.(ConfigService).GetConfig())
+
+    di.SetActiveProfiles("production", "eu")
+    resolved, err = di.ResolveWithProfiles("configService")
+    require.NoError(t, err)
+    assert.Equal(t, "Environment: production", resolved.(ConfigService).GetConfig())
+}
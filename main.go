@@ -381,7 +381,6 @@ func main() {
     paymentService := NewPaymentProcessor("sk_test_123")
     inventoryService := NewInventoryService()
     orderService := NewOrderService()
-    notificationService := NewNotificationService()
 
     // Register services with proper error handling
     log.Info("Registering services...")
@@ -397,7 +396,9 @@ func main() {
         log.Fatalw("Failed to register order service", "error", err)
     }
 
-    if err := di.Register("notificationService", notificationService, container.Prototype); err != nil {
+    if err := di.RegisterPrototypeFactory("notificationService", func() interface{} {
+        return NewNotificationService()
+    }); err != nil {
         log.Fatalw("Failed to register notification service", "error", err)
     }
 
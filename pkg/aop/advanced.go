@@ -0,0 +1,105 @@
+// pkg/aop/advanced.go
+package aop
+
+import (
+    "fmt"
+)
+
+// AdvancedAspect is a richer alternative to Aspect with one method per advice phase,
+// so a single aspect can act both before and after a method without switching on Kind.
+// Embed BaseAdvancedAspect to implement only the phases you care about.
+type AdvancedAspect interface {
+    // PointCut defines which methods this aspect applies to
+    PointCut() string
+
+    Before(jp *JoinPoint) error         // Runs before the method
+    After(jp *JoinPoint) error          // Runs after the method, regardless of outcome
+    AfterReturning(jp *JoinPoint) error // Runs after the method returns without error
+    AfterThrowing(jp *JoinPoint) error  // Runs after the method returns an error
+}
+
+// BaseAdvancedAspect provides no-op phase methods for embedding in advanced aspects
+type BaseAdvancedAspect struct{}
+
+func (BaseAdvancedAspect) Before(jp *JoinPoint) error         { return nil }
+func (BaseAdvancedAspect) After(jp *JoinPoint) error          { return nil }
+func (BaseAdvancedAspect) AfterReturning(jp *JoinPoint) error { return nil }
+func (BaseAdvancedAspect) AfterThrowing(jp *JoinPoint) error  { return nil }
+
+// advancedPhases are the kinds an advanced aspect is split into, in execution order
+var advancedPhases = []AspectKind{Before, After, AfterReturning, AfterThrowing}
+
+// phaseAspect adapts one phase of an AdvancedAspect to the simple Aspect interface,
+// so advanced aspects run through the same ordering, grouping and toggling as simple ones
+type phaseAspect struct {
+    advanced AdvancedAspect
+    kind     AspectKind
+}
+
+// AdaptAdvanced returns one simple Aspect per phase of an advanced aspect.
+// Ordered, Toggleable and SoftFail are honoured on the advanced aspect itself, see Underlying.
+func AdaptAdvanced(advanced AdvancedAspect) []Aspect {
+    aspects := make([]Aspect, len(advancedPhases))
+    for i, kind := range advancedPhases {
+        aspects[i] = &phaseAspect{advanced: advanced, kind: kind}
+    }
+    return aspects
+}
+
+func (p *phaseAspect) Kind() AspectKind { return p.kind }
+func (p *phaseAspect) PointCut() string { return p.advanced.PointCut() }
+
+// Advice dispatches to the phase method of the wrapped aspect
+func (p *phaseAspect) Advice(jp *JoinPoint) error {
+    switch p.kind {
+    case Before:
+        return p.advanced.Before(jp)
+    case After:
+        return p.advanced.After(jp)
+    case AfterReturning:
+        return p.advanced.AfterReturning(jp)
+    case AfterThrowing:
+        return p.advanced.AfterThrowing(jp)
+    default:
+        return fmt.Errorf("unsupported advanced aspect phase: %d", p.kind)
+    }
+}
+
+// Underlying returns the aspect as registered by the user, unwrapping phase adapters
+func Underlying(aspect Aspect) interface{} {
+    if phase, ok := aspect.(*phaseAspect); ok {
+        return phase.advanced
+    }
+    return aspect
+}
+
+// AddAdvancedAspect registers every phase of an advanced aspect with the manager
+func (am *AspectManager) AddAdvancedAspect(advanced AdvancedAspect) {
+    for _, aspect := range AdaptAdvanced(advanced) {
+        am.AddAspect(aspect)
+    }
+}
+
+// ExecutePhase runs the advice of the enabled aspects of the given kind whose pointcut
+// matches the join point, calling the matching phase method of advanced aspects.
+// AfterThrowing advice with an ErrorTypeFilter is skipped for errors it does not handle.
+func (am *AspectManager) ExecutePhase(kind AspectKind, jp *JoinPoint) error {
+    for _, aspect := range am.GetMatchingAspects(jp) {
+        if aspect.Kind() != kind {
+            continue
+        }
+        if kind == AfterThrowing && !HandlesError(aspect, jp.Error) {
+            continue
+        }
+        if err := aspect.Advice(jp); err != nil {
+            if IsSoftFail(aspect) {
+                am.log.Warnw("Soft-fail aspect failed, continuing",
+                    "aspect", fmt.Sprintf("%T", Underlying(aspect)),
+                    "error", err)
+                continue
+            }
+            return err
+        }
+    }
+    return nil
+}
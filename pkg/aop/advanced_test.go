@@ -0,0 +1,70 @@
+package aop
+
+import (
+    "errors"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+type timingAspect struct {
+    BaseAdvancedAspect
+    events []string
+}
+
+func (a *timingAspect) PointCut() string { return "userRepo.*" }
+
+func (a *timingAspect) Before(jp *JoinPoint) error {
+    a.events = append(a.events, "before "+jp.Signature())
+    return nil
+}
+
+func (a *timingAspect) After(jp *JoinPoint) error {
+    a.events = append(a.events, "after "+jp.Signature())
+    return nil
+}
+
+func TestAspectManager_AdvancedAspect(t *testing.T) {
+    am := NewAspectManager()
+    aspect := &timingAspect{}
+    am.AddAdvancedAspect(aspect)
+    simple := &countingAspect{pointcut: "userRepo.*"}
+    am.AddAspect(simple)
+
+    jp, err := NewJoinPoint(&userRepo{}, "GetUser", 1)
+    require.NoError(t, err)
+
+    require.NoError(t, am.ExecutePhase(Before, jp))
+    assert.Equal(t, []string{"before userRepo.GetUser"}, aspect.events)
+    assert.Equal(t, 1, simple.calls, "simple Before aspects still run")
+
+    require.NoError(t, am.ExecutePhase(After, jp))
+    require.NoError(t, am.ExecutePhase(AfterReturning, jp))
+    assert.Equal(t, []string{"before userRepo.GetUser", "after userRepo.GetUser"}, aspect.events)
+    assert.Equal(t, 1, simple.calls)
+}
+
+type failingAdvancedAspect struct {
+    BaseAdvancedAspect
+}
+
+func (a *failingAdvancedAspect) PointCut() string           { return ".*" }
+func (a *failingAdvancedAspect) Before(jp *JoinPoint) error { return errors.New("denied") }
+func (a *failingAdvancedAspect) SoftFail() bool             { return true }
+
+func TestAdaptAdvanced(t *testing.T) {
+    advanced := &failingAdvancedAspect{}
+    aspects := AdaptAdvanced(advanced)
+    require.Len(t, aspects, 4)
+
+    kinds := make([]AspectKind, len(aspects))
+    for i, aspect := range aspects {
+        kinds[i] = aspect.Kind()
+        assert.Same(t, advanced, Underlying(aspect))
+        assert.True(t, IsSoftFail(aspect), "SoftFail is read from the advanced aspect")
+    }
+    assert.Equal(t, []AspectKind{Before, After, AfterReturning, AfterThrowing}, kinds)
+    assert.EqualError(t, aspects[0].Advice(&JoinPoint{}), "denied")
+    assert.NoError(t, aspects[1].Advice(&JoinPoint{}))
+}
@@ -0,0 +1,20 @@
+// pkg/aop/around.go
+package aop
+
+// WrappingAspect is an Around aspect that wraps the method call itself instead of only running
+// ahead of it. When several wrapping aspects match a method they nest in execution order: the
+// first wraps the second, which wraps the method. Wrap runs its own code around proceed, which
+// calls the next wrapping aspect or finally the method; the method's results land in
+// jp.ReturnVals and jp.Error, and proceed only returns errors of inner advice.
+// Not calling proceed skips the method, like aborting the join point.
+// Advice is still used where no method call is being wrapped, e.g. by ExecuteAspects.
+type WrappingAspect interface {
+    Aspect
+    Wrap(jp *JoinPoint, proceed func() error) error
+}
+
+// IsWrapping reports whether aspect is an Around aspect that wraps the method call
+func IsWrapping(aspect Aspect) bool {
+    _, ok := aspect.(WrappingAspect)
+    return ok && aspect.Kind() == Around
+}
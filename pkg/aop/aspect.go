@@ -2,7 +2,15 @@
 package aop
 
 import (
+    "context"
+    "errors"
+    "fmt"
     "reflect"
+    "regexp"
+    "sort"
+    "strings"
+    "di-extended/pkg/logger"
+    "go.uber.org/zap"
 )
 
 // AspectKind represents different types of aspect execution points
@@ -17,6 +25,24 @@ const (
     AfterThrowing                     // Execute after method throws an error
 )
 
+// String returns the name of the aspect kind, e.g. "Around"
+func (k AspectKind) String() string {
+    switch k {
+    case Before:
+        return "Before"
+    case After:
+        return "After"
+    case Around:
+        return "Around"
+    case AfterReturning:
+        return "AfterReturning"
+    case AfterThrowing:
+        return "AfterThrowing"
+    default:
+        return fmt.Sprintf("AspectKind(%d)", int(k))
+    }
+}
+
 // JoinPoint represents the context at which an aspect intercepts the program
 // It contains all information about the method being executed
 type JoinPoint struct {
@@ -25,6 +51,47 @@ type JoinPoint struct {
     Args       []interface{}     // Arguments passed to the method
     ReturnVals []interface{}     // Values returned by the method
     Error      error            // Any error that occurred during method execution
+    Ctx        context.Context  // Context propagated by InvokeCtx; aspects may replace it with a derived one
+    Abort      bool             // Set by Before or Around advice to skip the method; see ErrAbort
+}
+
+// ErrAbort may be returned by Before or Around advice to abort the join point, which is the
+// same as setting jp.Abort. An aborted method is not called and the remaining Before and Around
+// advice is skipped, but After advice still runs. Aborting is not a failure: the caller gets
+// jp.ReturnVals (zero values unless the advice set them) and jp.Error, which advice may set.
+var ErrAbort = errors.New("join point aborted")
+
+// Signature returns the "Type.Method" name of the join point
+// This is the string that pointcut expressions are matched against
+func (jp *JoinPoint) Signature() string {
+    typeName := ""
+    if t := reflect.TypeOf(jp.Target); t != nil {
+        for t.Kind() == reflect.Ptr {
+            t = t.Elem()
+        }
+        typeName = t.Name()
+    }
+    return typeName + "." + jp.Method.Name
+}
+
+// Matches reports whether a pointcut expression applies to the join point
+// Pointcuts are regular expressions matched against the join point signature,
+// or TypeGlob.MethodGlob patterns such as "*.Save*" where * matches any run of characters
+func Matches(pointcut string, jp *JoinPoint) bool {
+    if globPointcut.MatchString(pointcut) {
+        pointcut = globToRegexp(pointcut)
+    }
+    matched, err := regexp.MatchString(pointcut, jp.Signature())
+    return err == nil && matched
+}
+
+// globPointcut recognises TypeGlob.MethodGlob pointcuts: identifiers and * wildcards
+// around a single dot, with at least one wildcard
+var globPointcut = regexp.MustCompile(`^(?:[\w*]*\*[\w*]*\.[\w*]+|[\w*]+\.[\w*]*\*[\w*]*)$`)
+
+// globToRegexp converts a glob pointcut into an anchored regular expression
+func globToRegexp(glob string) string {
+    return "^" + strings.ReplaceAll(regexp.QuoteMeta(glob), `\*`, ".*") + "$"
 }
 
 // Aspect defines the interface for implementing cross-cutting concerns
@@ -42,17 +109,47 @@ type Aspect interface {
     Advice(jp *JoinPoint) error
 }
 
+// SoftFail marks aspects whose failures are not critical (metrics, logging)
+// Errors from a soft-fail aspect are logged and the aspect chain continues
+type SoftFail interface {
+    // SoftFail reports whether the aspect's errors should be swallowed
+    SoftFail() bool
+}
+
+// IsSoftFail reports whether an aspect opted into soft-fail behaviour
+func IsSoftFail(aspect Aspect) bool {
+    softFail, ok := Underlying(aspect).(SoftFail)
+    return ok && softFail.SoftFail()
+}
+
+// Ordered lets an aspect declare its precedence
+// Aspects with lower order values run first; unordered aspects keep insertion order after them
+type Ordered interface {
+    Order() int
+}
+
+// Toggleable lets an aspect be switched off without removing it from the manager
+type Toggleable interface {
+    Enabled() bool
+}
+
 // AspectManager handles the registration and execution of aspects
 // It acts as a container for all aspects in the application
 type AspectManager struct {
-    aspects []Aspect    // Slice of registered aspects
+    aspects        []Aspect            // Slice of registered aspects
+    groups         map[string][]int    // Registration indices of the members of each named group
+    disabledGroups map[string]bool     // Groups switched off by SetGroupEnabled
+    log            *zap.SugaredLogger
 }
 
 // NewAspectManager creates a new instance of AspectManager
 // Initializes with an empty slice of aspects
 func NewAspectManager() *AspectManager {
     return &AspectManager{
-        aspects: make([]Aspect, 0),
+        aspects:        make([]Aspect, 0),
+        groups:         make(map[string][]int),
+        disabledGroups: make(map[string]bool),
+        log:            logger.Get(),
     }
 }
 
@@ -62,19 +159,151 @@ func (am *AspectManager) AddAspect(aspect Aspect) {
     am.aspects = append(am.aspects, aspect)
 }
 
-// GetAspects returns all registered aspects
+// GetAspects returns a copy of all registered aspects in insertion order
 // Useful for inspection and debugging
 func (am *AspectManager) GetAspects() []Aspect {
-    return am.aspects
+    return append([]Aspect(nil), am.aspects...)
+}
+
+// GetOrderedAspects returns a copy of the aspects that currently execute, in execution order
+// Disabled aspects are left out and Ordered aspects come first by order value
+func (am *AspectManager) GetOrderedAspects() []Aspect {
+    return am.activeAspects()
+}
+
+// AddToGroup places an aspect in a named group, registering it first if needed
+// An aspect may belong to several groups and is skipped while any of them is disabled.
+// An aspect whose value is not comparable, such as a struct holding a slice, cannot be
+// recognised as already registered, so it is registered again; pass a pointer to avoid that.
+func (am *AspectManager) AddToGroup(group string, aspect Aspect) {
+    index := am.indexOf(aspect)
+    if index < 0 {
+        am.AddAspect(aspect)
+        index = len(am.aspects) - 1
+    }
+    for _, member := range am.groups[group] {
+        if member == index {
+            return
+        }
+    }
+    am.groups[group] = append(am.groups[group], index)
+}
+
+// SetGroupEnabled switches every aspect of a group on or off
+func (am *AspectManager) SetGroupEnabled(group string, enabled bool) {
+    if enabled {
+        delete(am.disabledGroups, group)
+    } else {
+        am.disabledGroups[group] = true
+    }
+    am.log.Infow("Aspect group toggled",
+        "group", group,
+        "enabled", enabled,
+        "members", len(am.groups[group]))
+}
+
+// IsEnabled reports whether an aspect currently takes part in execution
+func (am *AspectManager) IsEnabled(aspect Aspect) bool {
+    if index := am.indexOf(aspect); index >= 0 {
+        return am.enabledAt(index)
+    }
+    return toggledOn(aspect)
+}
+
+// enabledAt reports whether the aspect registered at index takes part in execution
+func (am *AspectManager) enabledAt(index int) bool {
+    if !toggledOn(am.aspects[index]) {
+        return false
+    }
+    for group := range am.disabledGroups {
+        for _, member := range am.groups[group] {
+            if member == index {
+                return false
+            }
+        }
+    }
+    return true
+}
+
+// toggledOn reports whether an aspect is not switched off through Toggleable
+func toggledOn(aspect Aspect) bool {
+    toggleable, ok := Underlying(aspect).(Toggleable)
+    return !ok || toggleable.Enabled()
+}
+
+// indexOf returns the registration index of an aspect, or -1 when it is not registered
+func (am *AspectManager) indexOf(aspect Aspect) int {
+    for i, registered := range am.aspects {
+        if sameAspect(registered, aspect) {
+            return i
+        }
+    }
+    return -1
+}
+
+// sameAspect compares two aspects like == would, except that values of a type that is not
+// comparable, which == panics on, are never the same
+func sameAspect(a, b Aspect) bool {
+    if reflect.TypeOf(a) != reflect.TypeOf(b) {
+        return false
+    }
+    if a != nil && !reflect.ValueOf(a).Comparable() {
+        return false
+    }
+    return a == b
+}
+
+// GetMatchingAspects returns the enabled aspects whose pointcut, and JoinPointMatcher if any,
+// matches the join point. Ordered aspects come first by order value, the rest follow in the order they were added
+func (am *AspectManager) GetMatchingAspects(jp *JoinPoint) []Aspect {
+    matching := make([]Aspect, 0, len(am.aspects))
+    for _, aspect := range am.activeAspects() {
+        if Matches(aspect.PointCut(), jp) && matchesJoinPoint(aspect, jp) {
+            matching = append(matching, aspect)
+        }
+    }
+    return matching
+}
+
+// activeAspects returns the enabled aspects in execution order
+func (am *AspectManager) activeAspects() []Aspect {
+    active := make([]Aspect, 0, len(am.aspects))
+    for i, aspect := range am.aspects {
+        if am.enabledAt(i) {
+            active = append(active, aspect)
+        }
+    }
+    sortByOrder(active)
+    return active
+}
+
+// sortByOrder stably sorts aspects by their Ordered value, unordered aspects last
+func sortByOrder(aspects []Aspect) {
+    sort.SliceStable(aspects, func(i, j int) bool {
+        left, leftOrdered := Underlying(aspects[i]).(Ordered)
+        right, rightOrdered := Underlying(aspects[j]).(Ordered)
+        switch {
+        case leftOrdered && rightOrdered:
+            return left.Order() < right.Order()
+        default:
+            return leftOrdered && !rightOrdered
+        }
+    })
 }
 
 // ExecuteAspects runs all applicable aspects for a given join point
 // This is called whenever an intercepted method is executed
 func (am *AspectManager) ExecuteAspects(jp *JoinPoint) error {
-    // Iterate through all registered aspects
-    for _, aspect := range am.aspects {
+    // Iterate through all enabled aspects in execution order
+    for _, aspect := range am.activeAspects() {
         // Execute each aspect's advice
         if err := aspect.Advice(jp); err != nil {
+            if IsSoftFail(aspect) {
+                am.log.Warnw("Soft-fail aspect failed, continuing",
+                    "aspect", reflect.TypeOf(Underlying(aspect)).String(),
+                    "error", err)
+                continue
+            }
             return err
         }
     }
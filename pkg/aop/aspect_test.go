@@ -0,0 +1,162 @@
+package aop
+
+import (
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+type countingAspect struct {
+    pointcut string
+    calls    int
+}
+
+func (a *countingAspect) Kind() AspectKind { return Before }
+func (a *countingAspect) PointCut() string { return a.pointcut }
+
+func (a *countingAspect) Advice(jp *JoinPoint) error {
+    a.calls++
+    return nil
+}
+
+func TestAspectManager_Groups(t *testing.T) {
+    am := NewAspectManager()
+    auditSave := &countingAspect{pointcut: ".*"}
+    auditDelete := &countingAspect{pointcut: ".*"}
+    logging := &countingAspect{pointcut: ".*"}
+
+    am.AddToGroup("audit", auditSave)
+    am.AddToGroup("audit", auditDelete)
+    am.AddToGroup("compliance", auditDelete) // member of two groups
+    am.AddAspect(logging)
+    assert.Len(t, am.GetAspects(), 3)
+
+    am.SetGroupEnabled("audit", false)
+    require.NoError(t, am.ExecuteAspects(&JoinPoint{}))
+    assert.Equal(t, 0, auditSave.calls)
+    assert.Equal(t, 0, auditDelete.calls)
+    assert.Equal(t, 1, logging.calls)
+
+    // Re-enabling the group brings both back
+    am.SetGroupEnabled("audit", true)
+    require.NoError(t, am.ExecuteAspects(&JoinPoint{}))
+    assert.Equal(t, 1, auditSave.calls)
+    assert.Equal(t, 1, auditDelete.calls)
+    assert.Equal(t, 2, logging.calls)
+
+    // Disabling any group of a multi-group aspect disables it
+    am.SetGroupEnabled("compliance", false)
+    jp := &JoinPoint{Target: &userRepo{}}
+    assert.Equal(t, []Aspect{auditSave, logging}, am.GetMatchingAspects(jp))
+}
+
+// taggedAspect is a value aspect holding a slice, so == on it would panic
+type taggedAspect struct {
+    tags  []string
+    calls *int
+}
+
+func (a taggedAspect) Kind() AspectKind { return Before }
+func (a taggedAspect) PointCut() string { return ".*" }
+
+func (a taggedAspect) Advice(jp *JoinPoint) error {
+    *a.calls++
+    return nil
+}
+
+func TestAspectManager_GroupsWithIncomparableAspects(t *testing.T) {
+    am := NewAspectManager()
+    var auditCalls, metricCalls int
+    audit := taggedAspect{tags: []string{"audit"}, calls: &auditCalls}
+    metrics := taggedAspect{tags: []string{"metrics"}, calls: &metricCalls}
+
+    am.AddToGroup("audit", audit)
+    am.AddAspect(metrics)
+    assert.Len(t, am.GetAspects(), 2)
+    assert.True(t, am.IsEnabled(metrics))
+
+    am.SetGroupEnabled("audit", false)
+    require.NoError(t, am.ExecuteAspects(&JoinPoint{}))
+    assert.Equal(t, 0, auditCalls)
+    assert.Equal(t, 1, metricCalls)
+    assert.Len(t, am.GetOrderedAspects(), 1)
+}
+
+func TestMatches(t *testing.T) {
+    saveUser, err := NewJoinPoint(&userRepo{}, "SaveUser", "alice")
+    require.NoError(t, err)
+    getUser, err := NewJoinPoint(&userRepo{}, "GetUser", 1)
+    require.NoError(t, err)
+
+    tests := []struct {
+        pointcut string
+        jp       *JoinPoint
+        expected bool
+    }{
+        {pointcut: "*.Save*", jp: saveUser, expected: true},
+        {pointcut: "*.Save*", jp: getUser, expected: false},
+        {pointcut: "user*.*User", jp: getUser, expected: true},
+        {pointcut: "order*.*", jp: getUser, expected: false},
+        {pointcut: "*.User", jp: getUser, expected: false}, // globs are anchored
+        {pointcut: "userRepo.*", jp: getUser, expected: true},
+        {pointcut: ".*", jp: saveUser, expected: true}, // regular expressions still work
+        {pointcut: "Get(User|Order)", jp: getUser, expected: true},
+        {pointcut: "[", jp: getUser, expected: false},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.pointcut+" "+tt.jp.Signature(), func(t *testing.T) {
+            assert.Equal(t, tt.expected, Matches(tt.pointcut, tt.jp))
+        })
+    }
+}
+
+type orderedCountingAspect struct {
+    countingAspect
+    order int
+}
+
+func (a *orderedCountingAspect) Order() int { return a.order }
+
+func TestAspectManager_GetOrderedAspects(t *testing.T) {
+    am := NewAspectManager()
+    unordered := &countingAspect{pointcut: ".*"}
+    late := &orderedCountingAspect{countingAspect: countingAspect{pointcut: ".*"}, order: 20}
+    early := &orderedCountingAspect{countingAspect: countingAspect{pointcut: ".*"}, order: 10}
+    disabled := &countingAspect{pointcut: ".*"}
+    am.AddAspect(unordered)
+    am.AddAspect(late)
+    am.AddAspect(early)
+    am.AddToGroup("off", disabled)
+    am.SetGroupEnabled("off", false)
+
+    assert.Equal(t, []Aspect{unordered, late, early, disabled}, am.GetAspects())
+    assert.Equal(t, []Aspect{early, late, unordered}, am.GetOrderedAspects())
+
+    // Both return copies
+    am.GetAspects()[0] = nil
+    am.GetOrderedAspects()[0] = nil
+    assert.Equal(t, []Aspect{unordered, late, early, disabled}, am.GetAspects())
+    assert.Equal(t, []Aspect{early, late, unordered}, am.GetOrderedAspects())
+}
+
+func TestAspectKind_String(t *testing.T) {
+    tests := []struct {
+        kind     AspectKind
+        expected string
+    }{
+        {kind: Before, expected: "Before"},
+        {kind: After, expected: "After"},
+        {kind: Around, expected: "Around"},
+        {kind: AfterReturning, expected: "AfterReturning"},
+        {kind: AfterThrowing, expected: "AfterThrowing"},
+        {kind: AspectKind(42), expected: "AspectKind(42)"},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.expected, func(t *testing.T) {
+            assert.Equal(t, tt.expected, tt.kind.String())
+        })
+    }
+}
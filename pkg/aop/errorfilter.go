@@ -0,0 +1,36 @@
+// pkg/aop/errorfilter.go
+package aop
+
+import (
+    "errors"
+    "reflect"
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// ErrorTypeFilter can be implemented by AfterThrowing aspects that only handle some errors,
+// e.g. retrying on a *RetryableError. The advice runs only when jp.Error matches the type with
+// errors.As, so wrapped errors match as well.
+type ErrorTypeFilter interface {
+    // ErrorType returns the error type to match: a type implementing error, such as
+    // reflect.TypeOf(&RetryableError{}), or an interface type
+    ErrorType() reflect.Type
+}
+
+// HandlesError reports whether an aspect's advice applies to err.
+// Aspects without an ErrorTypeFilter handle every error.
+func HandlesError(aspect Aspect, err error) bool {
+    filter, ok := Underlying(aspect).(ErrorTypeFilter)
+    if !ok {
+        return true
+    }
+    target := filter.ErrorType()
+    if err == nil || target == nil {
+        return false
+    }
+    // errors.As panics on targets that are neither errors nor interfaces
+    if target.Kind() != reflect.Interface && !target.Implements(errorType) {
+        return false
+    }
+    return errors.As(err, reflect.New(target).Interface())
+}
@@ -0,0 +1,68 @@
+package aop
+
+import (
+    "errors"
+    "fmt"
+    "reflect"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+type retryableError struct {
+    attempts int
+}
+
+func (e *retryableError) Error() string { return fmt.Sprintf("retryable after %d attempts", e.attempts) }
+
+type timeoutError interface {
+    Timeout() bool
+}
+
+type filteringAspect struct {
+    countingAspect
+    errorType reflect.Type
+}
+
+func (a *filteringAspect) Kind() AspectKind        { return AfterThrowing }
+func (a *filteringAspect) ErrorType() reflect.Type { return a.errorType }
+
+func TestHandlesError(t *testing.T) {
+    retryable := &retryableError{attempts: 3}
+
+    tests := []struct {
+        name   string
+        aspect Aspect
+        err    error
+        want   bool
+    }{
+        {"no filter", &countingAspect{}, errors.New("boom"), true},
+        {"matching type", &filteringAspect{errorType: reflect.TypeOf(retryable)}, retryable, true},
+        {"wrapped match", &filteringAspect{errorType: reflect.TypeOf(retryable)}, fmt.Errorf("save: %w", retryable), true},
+        {"other type", &filteringAspect{errorType: reflect.TypeOf(retryable)}, errors.New("boom"), false},
+        {"interface type", &filteringAspect{errorType: reflect.TypeOf((*timeoutError)(nil)).Elem()}, retryable, false},
+        {"not an error type", &filteringAspect{errorType: reflect.TypeOf(0)}, retryable, false},
+        {"nil type", &filteringAspect{}, retryable, false},
+        {"nil error", &filteringAspect{errorType: reflect.TypeOf(retryable)}, nil, false},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            assert.Equal(t, tt.want, HandlesError(tt.aspect, tt.err))
+        })
+    }
+}
+
+func TestAspectManager_ExecutePhaseErrorTypeFilter(t *testing.T) {
+    am := NewAspectManager()
+    filtered := &filteringAspect{countingAspect: countingAspect{pointcut: ".*"}, errorType: reflect.TypeOf(&retryableError{})}
+    am.AddAspect(filtered)
+
+    jp := &JoinPoint{Target: &userRepo{}, Error: errors.New("boom")}
+    require.NoError(t, am.ExecutePhase(AfterThrowing, jp))
+    assert.Equal(t, 0, filtered.calls, "other error types are skipped")
+
+    jp.Error = fmt.Errorf("save: %w", &retryableError{attempts: 1})
+    require.NoError(t, am.ExecutePhase(AfterThrowing, jp))
+    assert.Equal(t, 1, filtered.calls)
+}
@@ -0,0 +1,74 @@
+// pkg/aop/joinpoint.go
+package aop
+
+import (
+    "fmt"
+    "reflect"
+)
+
+// NewJoinPoint builds a join point for calling methodName on target with args
+// The method is looked up by reflection and args are checked against its signature,
+// so unknown methods, wrong arity and mismatched argument types are reported up front
+func NewJoinPoint(target interface{}, methodName string, args ...interface{}) (*JoinPoint, error) {
+    if target == nil {
+        return nil, fmt.Errorf("cannot build join point for method %s on nil target", methodName)
+    }
+
+    method, ok := reflect.TypeOf(target).MethodByName(methodName)
+    if !ok {
+        return nil, fmt.Errorf("method %s not found on %T", methodName, target)
+    }
+
+    if _, err := BuildArgs(method, 0, args); err != nil {
+        return nil, err
+    }
+
+    return &JoinPoint{
+        Target: target,
+        Method: method,
+        Args:   args,
+    }, nil
+}
+
+// BuildArgs converts args into reflect values for calling method, validating arity and types
+// The first skip parameters after the receiver are supplied by the caller and excluded from args
+// Nil arguments become zero values for parameters of nilable kinds
+func BuildArgs(method reflect.Method, skip int, args []interface{}) ([]reflect.Value, error) {
+    methodType := method.Type
+    fixed := methodType.NumIn() - 1 - skip // exclude receiver and skipped parameters
+    if methodType.IsVariadic() {
+        fixed--
+        if len(args) < fixed {
+            return nil, fmt.Errorf("method %s expects at least %d arguments, got %d", method.Name, fixed, len(args))
+        }
+    } else if len(args) != fixed {
+        return nil, fmt.Errorf("method %s expects %d arguments, got %d", method.Name, fixed, len(args))
+    }
+
+    values := make([]reflect.Value, 0, len(args))
+    for i, arg := range args {
+        var paramType reflect.Type
+        if i < fixed {
+            paramType = methodType.In(i + skip + 1)
+        } else {
+            paramType = methodType.In(methodType.NumIn() - 1).Elem()
+        }
+
+        if arg == nil {
+            switch paramType.Kind() {
+            case reflect.Interface, reflect.Ptr, reflect.Slice, reflect.Map, reflect.Func, reflect.Chan:
+                values = append(values, reflect.Zero(paramType))
+                continue
+            }
+            return nil, fmt.Errorf("argument %d of method %s cannot be nil", i, method.Name)
+        }
+
+        argValue := reflect.ValueOf(arg)
+        if !argValue.Type().AssignableTo(paramType) {
+            return nil, fmt.Errorf("argument %d of method %s: %v is not assignable to %v",
+                i, method.Name, argValue.Type(), paramType)
+        }
+        values = append(values, argValue)
+    }
+    return values, nil
+}
@@ -0,0 +1,77 @@
+package aop
+
+import (
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+type userRepo struct{}
+
+func (r *userRepo) GetUser(id int) string                      { return "user" }
+func (r *userRepo) SaveUser(name string, tags ...string) error { return nil }
+
+func TestNewJoinPoint(t *testing.T) {
+    repo := &userRepo{}
+
+    jp, err := NewJoinPoint(repo, "GetUser", 42)
+    require.NoError(t, err)
+    assert.Same(t, repo, jp.Target)
+    assert.Equal(t, "GetUser", jp.Method.Name)
+    assert.Equal(t, []interface{}{42}, jp.Args)
+    assert.Equal(t, "userRepo.GetUser", jp.Signature())
+
+    // Variadic methods accept any number of trailing arguments
+    _, err = NewJoinPoint(repo, "SaveUser", "alice", "admin", "ops")
+    assert.NoError(t, err)
+}
+
+func TestNewJoinPointErrors(t *testing.T) {
+    repo := &userRepo{}
+
+    tests := []struct {
+        name    string
+        target  interface{}
+        method  string
+        args    []interface{}
+        wantErr string
+    }{
+        {
+            name:    "unknown method",
+            target:  repo,
+            method:  "DeleteUser",
+            args:    []interface{}{1},
+            wantErr: "method DeleteUser not found",
+        },
+        {
+            name:    "arity mismatch",
+            target:  repo,
+            method:  "GetUser",
+            args:    []interface{}{},
+            wantErr: "expects 1 arguments, got 0",
+        },
+        {
+            name:    "type mismatch",
+            target:  repo,
+            method:  "GetUser",
+            args:    []interface{}{"42"},
+            wantErr: "string is not assignable to int",
+        },
+        {
+            name:    "nil target",
+            target:  nil,
+            method:  "GetUser",
+            args:    []interface{}{1},
+            wantErr: "nil target",
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            jp, err := NewJoinPoint(tt.target, tt.method, tt.args...)
+            assert.Nil(t, jp)
+            assert.ErrorContains(t, err, tt.wantErr)
+        })
+    }
+}
@@ -0,0 +1,71 @@
+// pkg/aop/metrics.go
+package aop
+
+import (
+    "sync"
+)
+
+// AspectMetrics holds the counters of one advised method
+type AspectMetrics struct {
+    Invocations uint64 // Calls that returned, with or without an error
+    Errors      uint64 // Calls that returned an error
+}
+
+// MetricsAspect is a built-in advanced aspect counting invocations and errors per matched
+// method, keyed by the join point signature such as "UserService.GetUser". Register it broadly
+// with AddAdvancedAspect and read the counters with Snapshot. Calls aborted by other advice
+// never run the method and are not counted. It is safe for concurrent use and soft-fails.
+type MetricsAspect struct {
+    BaseAdvancedAspect
+    pointcut string
+    mu       sync.Mutex
+    metrics  map[string]AspectMetrics
+}
+
+// NewMetricsAspect creates a metrics aspect for the methods matching pointcut, e.g. ".*Service.*"
+func NewMetricsAspect(pointcut string) *MetricsAspect {
+    return &MetricsAspect{
+        pointcut: pointcut,
+        metrics:  make(map[string]AspectMetrics),
+    }
+}
+
+func (a *MetricsAspect) PointCut() string { return a.pointcut }
+func (a *MetricsAspect) SoftFail() bool   { return true }
+
+// AfterReturning counts a successful invocation
+func (a *MetricsAspect) AfterReturning(jp *JoinPoint) error {
+    a.record(jp.Signature(), false)
+    return nil
+}
+
+// AfterThrowing counts a failed invocation
+func (a *MetricsAspect) AfterThrowing(jp *JoinPoint) error {
+    a.record(jp.Signature(), true)
+    return nil
+}
+
+// Snapshot returns a copy of the counters per method signature
+func (a *MetricsAspect) Snapshot() map[string]AspectMetrics {
+    a.mu.Lock()
+    defer a.mu.Unlock()
+
+    snapshot := make(map[string]AspectMetrics, len(a.metrics))
+    for signature, metrics := range a.metrics {
+        snapshot[signature] = metrics
+    }
+    return snapshot
+}
+
+// record counts one invocation of signature
+func (a *MetricsAspect) record(signature string, failed bool) {
+    a.mu.Lock()
+    defer a.mu.Unlock()
+
+    metrics := a.metrics[signature]
+    metrics.Invocations++
+    if failed {
+        metrics.Errors++
+    }
+    a.metrics[signature] = metrics
+}
@@ -0,0 +1,54 @@
+package aop
+
+import (
+    "errors"
+    "sync"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+func TestMetricsAspect(t *testing.T) {
+    am := NewAspectManager()
+    metrics := NewMetricsAspect("userRepo.*")
+    am.AddAdvancedAspect(metrics)
+
+    // complete runs the phases an invocation ends with
+    complete := func(jp *JoinPoint, err error) {
+        jp.Error = err
+        assert.NoError(t, am.ExecutePhase(After, jp))
+        if err != nil {
+            assert.NoError(t, am.ExecutePhase(AfterThrowing, jp))
+        } else {
+            assert.NoError(t, am.ExecutePhase(AfterReturning, jp))
+        }
+    }
+
+    var wg sync.WaitGroup
+    for i := 0; i < 5; i++ {
+        jp, err := NewJoinPoint(&userRepo{}, "GetUser", i)
+        require.NoError(t, err)
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            complete(jp, nil)
+        }()
+    }
+    wg.Wait()
+    for _, err := range []error{nil, errors.New("duplicate"), errors.New("duplicate")} {
+        jp, buildErr := NewJoinPoint(&userRepo{}, "SaveUser", "alice")
+        require.NoError(t, buildErr)
+        complete(jp, err)
+    }
+
+    snapshot := metrics.Snapshot()
+    assert.Equal(t, map[string]AspectMetrics{
+        "userRepo.GetUser":  {Invocations: 5},
+        "userRepo.SaveUser": {Invocations: 3, Errors: 2},
+    }, snapshot)
+
+    // The snapshot is a copy
+    snapshot["userRepo.GetUser"] = AspectMetrics{}
+    assert.Equal(t, uint64(5), metrics.Snapshot()["userRepo.GetUser"].Invocations)
+}
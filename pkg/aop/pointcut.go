@@ -0,0 +1,48 @@
+// pkg/aop/pointcut.go
+package aop
+
+import "reflect"
+
+// JoinPointMatcher can be implemented by aspects whose applicability depends on more than the
+// join point signature, e.g. on parameter types. An aspect implementing it only matches a join
+// point when both its PointCut expression and MatchesJoinPoint accept it.
+type JoinPointMatcher interface {
+    MatchesJoinPoint(jp *JoinPoint) bool
+}
+
+// ArgTypePointcut matches join points whose parameter at Index, counted without the receiver,
+// has a type assignable to Type, e.g. {Index: 0, Type: reflect.TypeOf((*context.Context)(nil)).Elem()}
+// for methods taking a context first. The declared parameter types of jp.Method are used when
+// it is set, otherwise the runtime types of jp.Args. It implements JoinPointMatcher, so embedding
+// it in an aspect restricts the aspect to such join points.
+type ArgTypePointcut struct {
+    Index int
+    Type  reflect.Type
+}
+
+var _ JoinPointMatcher = ArgTypePointcut{}
+
+// MatchesJoinPoint reports whether the join point's parameter at Index has a matching type
+func (p ArgTypePointcut) MatchesJoinPoint(jp *JoinPoint) bool {
+    if p.Type == nil || p.Index < 0 {
+        return false
+    }
+    if methodType := jp.Method.Type; methodType != nil {
+        // In(0) is the receiver
+        index := p.Index + 1
+        if index >= methodType.NumIn() {
+            return false
+        }
+        return methodType.In(index).AssignableTo(p.Type)
+    }
+    if p.Index >= len(jp.Args) || jp.Args[p.Index] == nil {
+        return false
+    }
+    return reflect.TypeOf(jp.Args[p.Index]).AssignableTo(p.Type)
+}
+
+// matchesJoinPoint applies an aspect's JoinPointMatcher, accepting aspects without one
+func matchesJoinPoint(aspect Aspect, jp *JoinPoint) bool {
+    matcher, ok := Underlying(aspect).(JoinPointMatcher)
+    return !ok || matcher.MatchesJoinPoint(jp)
+}
@@ -0,0 +1,68 @@
+package aop
+
+import (
+    "context"
+    "reflect"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+type lookupRepo struct{}
+
+func (r *lookupRepo) FindByName(name string) error               { return nil }
+func (r *lookupRepo) FindByID(ctx context.Context, id int) error { return nil }
+
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+// contextAspect applies to every method whose first argument is a context, through the
+// JoinPointMatcher promoted from the embedded ArgTypePointcut
+type contextAspect struct {
+    countingAspect
+    ArgTypePointcut
+}
+
+func TestArgTypePointcut(t *testing.T) {
+    byName, err := NewJoinPoint(&lookupRepo{}, "FindByName", "alice")
+    require.NoError(t, err)
+    byID, err := NewJoinPoint(&lookupRepo{}, "FindByID", context.Background(), 7)
+    require.NoError(t, err)
+
+    tests := []struct {
+        name     string
+        pointcut ArgTypePointcut
+        jp       *JoinPoint
+        want     bool
+    }{
+        {"declared context", ArgTypePointcut{Index: 0, Type: contextType}, byID, true},
+        {"declared string", ArgTypePointcut{Index: 0, Type: contextType}, byName, false},
+        {"second parameter", ArgTypePointcut{Index: 1, Type: reflect.TypeOf(0)}, byID, true},
+        {"index out of range", ArgTypePointcut{Index: 1, Type: contextType}, byName, false},
+        {"runtime args", ArgTypePointcut{Index: 0, Type: contextType}, &JoinPoint{Args: []interface{}{context.TODO()}}, true},
+        {"nil runtime arg", ArgTypePointcut{Index: 0, Type: contextType}, &JoinPoint{Args: []interface{}{nil}}, false},
+        {"no type", ArgTypePointcut{}, byID, false},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            assert.Equal(t, tt.want, tt.pointcut.MatchesJoinPoint(tt.jp))
+        })
+    }
+}
+
+func TestAspectManager_JoinPointMatcher(t *testing.T) {
+    am := NewAspectManager()
+    aspect := &contextAspect{
+        countingAspect:  countingAspect{pointcut: "lookupRepo.*"},
+        ArgTypePointcut: ArgTypePointcut{Index: 0, Type: contextType},
+    }
+    am.AddAspect(aspect)
+
+    byName, err := NewJoinPoint(&lookupRepo{}, "FindByName", "alice")
+    require.NoError(t, err)
+    byID, err := NewJoinPoint(&lookupRepo{}, "FindByID", context.Background(), 7)
+    require.NoError(t, err)
+
+    assert.Empty(t, am.GetMatchingAspects(byName))
+    assert.Equal(t, []Aspect{aspect}, am.GetMatchingAspects(byID))
+}
@@ -0,0 +1,42 @@
+// pkg/container/aspect_summary.go
+package container
+
+import (
+    "fmt"
+
+    "di-extended/pkg/aop"
+)
+
+// AspectSummaryEntry describes one registered aspect for diagnostics
+type AspectSummaryEntry struct {
+    Type     string         // Concrete type of the aspect, e.g. "*services.LoggingAspect"
+    Kind     aop.AspectKind // When the aspect's advice runs
+    PointCut string         // Pointcut expression the aspect applies to
+    Order    int            // Declared order, only meaningful when Ordered is true
+    Ordered  bool           // Whether the aspect implements aop.Ordered
+    Enabled  bool           // Whether the aspect currently takes part in execution
+}
+
+// AspectSummary reports every registered aspect in registration order.
+// It is read-only and meant for admin or debugging endpoints.
+func (c *Container) AspectSummary() []AspectSummaryEntry {
+    c.mu.RLock()
+    defer c.mu.RUnlock()
+
+    aspects := c.aspectManager.GetAspects()
+    summary := make([]AspectSummaryEntry, 0, len(aspects))
+    for _, aspect := range aspects {
+        entry := AspectSummaryEntry{
+            Type:     fmt.Sprintf("%T", aop.Underlying(aspect)),
+            Kind:     aspect.Kind(),
+            PointCut: aspect.PointCut(),
+            Enabled:  c.aspectManager.IsEnabled(aspect),
+        }
+        if ordered, ok := aop.Underlying(aspect).(aop.Ordered); ok {
+            entry.Order = ordered.Order()
+            entry.Ordered = true
+        }
+        summary = append(summary, entry)
+    }
+    return summary
+}
@@ -0,0 +1,47 @@
+package container
+
+import (
+    "testing"
+
+    "di-extended/pkg/aop"
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+type orderedAspect struct {
+    recordingAspect
+    order   int
+    enabled bool
+}
+
+func (a *orderedAspect) Order() int    { return a.order }
+func (a *orderedAspect) Enabled() bool { return a.enabled }
+
+func TestContainer_AspectSummary(t *testing.T) {
+    container := NewContainer()
+    container.AddAspect(&recordingAspect{kind: aop.Before, pointcut: ".*Service.*"})
+    container.AddAspect(&orderedAspect{
+        recordingAspect: recordingAspect{kind: aop.Around, pointcut: "OrderService.CreateOrder"},
+        order:           5,
+        enabled:         false,
+    })
+
+    summary := container.AspectSummary()
+    require.Len(t, summary, 2)
+
+    assert.Equal(t, AspectSummaryEntry{
+        Type:     "*container.recordingAspect",
+        Kind:     aop.Before,
+        PointCut: ".*Service.*",
+        Enabled:  true,
+    }, summary[0])
+
+    assert.Equal(t, AspectSummaryEntry{
+        Type:     "*container.orderedAspect",
+        Kind:     aop.Around,
+        PointCut: "OrderService.CreateOrder",
+        Order:    5,
+        Ordered:  true,
+        Enabled:  false,
+    }, summary[1])
+}
@@ -0,0 +1,29 @@
+// pkg/container/assert.go
+package container
+
+import (
+    "fmt"
+    "reflect"
+)
+
+// AssertImplements resolves qualifier and checks that the service is assignable to T.
+// It is meant for tests that want to catch a binding to the wrong type early, e.g.
+//
+//	if err := container.AssertImplements[services.UserService](c, "userService"); err != nil { ... }
+func AssertImplements[T any](c *Container, qualifier string) error {
+    expected := reflect.TypeOf((*T)(nil)).Elem()
+
+    service, err := c.Resolve(qualifier)
+    if err != nil {
+        return fmt.Errorf("cannot check %s implements %v: %w", qualifier, expected, err)
+    }
+
+    if _, ok := service.(T); !ok {
+        c.log.Errorw("Service does not implement expected type",
+            "qualifier", qualifier,
+            "expectedType", expected,
+            "actualType", reflect.TypeOf(service))
+        return fmt.Errorf("service %s of type %T does not implement %v", qualifier, service, expected)
+    }
+    return nil
+}
@@ -0,0 +1,24 @@
+package container
+
+import (
+    "fmt"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+func TestAssertImplements(t *testing.T) {
+    container := NewContainer()
+    require.NoError(t, container.Register("testService", &testServiceImpl{name: "test"}, Singleton))
+
+    assert.NoError(t, AssertImplements[TestService](container, "testService"))
+    assert.NoError(t, AssertImplements[*testServiceImpl](container, "testService"))
+
+    err := AssertImplements[fmt.Stringer](container, "testService")
+    require.Error(t, err)
+    assert.Equal(t, "service testService of type *container.testServiceImpl does not implement fmt.Stringer", err.Error())
+
+    err = AssertImplements[TestService](container, "missing")
+    assert.ErrorContains(t, err, "no service found for qualifier: missing")
+}
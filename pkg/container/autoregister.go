@@ -0,0 +1,67 @@
+// pkg/container/autoregister.go
+package container
+
+import (
+    "fmt"
+    "reflect"
+    "unicode"
+    "unicode/utf8"
+)
+
+// SelfRegistering is implemented by registration objects that know how to register
+// themselves and their dependencies, so a package can expose a single module value
+type SelfRegistering interface {
+    Register(c *Container) error
+}
+
+// AutoRegister calls Register on each service in order and stops at the first failure
+func (c *Container) AutoRegister(services ...SelfRegistering) error {
+    for i, service := range services {
+        if service == nil {
+            c.log.Errorw("Cannot auto-register nil service", "index", i)
+            return fmt.Errorf("cannot auto-register nil service at index %d", i)
+        }
+        c.log.Infow("Auto-registering service", "type", fmt.Sprintf("%T", service))
+        if err := service.Register(c); err != nil {
+            c.log.Errorw("Auto-registration failed", "type", fmt.Sprintf("%T", service), "error", err)
+            return fmt.Errorf("auto-registration of %T failed: %w", service, err)
+        }
+    }
+    return nil
+}
+
+// AutoBind registers each service as a singleton under a qualifier derived from its concrete
+// type name with the first letter lowercased, e.g. *userService and *UserService both become
+// "userService". Type-based resolution (ResolveByType) then finds a service by any interface
+// it implements. It stops at the first failure, such as two services with the same type name.
+func (c *Container) AutoBind(services ...interface{}) error {
+    for i, service := range services {
+        qualifier, err := derivedQualifier(service)
+        if err != nil {
+            c.log.Errorw("Cannot auto-bind service", "index", i, "error", err)
+            return fmt.Errorf("cannot auto-bind service at index %d: %w", i, err)
+        }
+        c.log.Infow("Auto-binding service", "qualifier", qualifier, "type", fmt.Sprintf("%T", service))
+        if err := c.Register(qualifier, service, Singleton); err != nil {
+            return fmt.Errorf("auto-binding of %T failed: %w", service, err)
+        }
+    }
+    return nil
+}
+
+// derivedQualifier returns the lower-camel-case name of a service's concrete type
+func derivedQualifier(service interface{}) (string, error) {
+    if service == nil {
+        return "", fmt.Errorf("service is nil")
+    }
+    t := reflect.TypeOf(service)
+    for t.Kind() == reflect.Ptr {
+        t = t.Elem()
+    }
+    name := t.Name()
+    if name == "" {
+        return "", fmt.Errorf("type %T has no name to derive a qualifier from", service)
+    }
+    first, size := utf8.DecodeRuneInString(name)
+    return string(unicode.ToLower(first)) + name[size:], nil
+}
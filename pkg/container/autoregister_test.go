@@ -0,0 +1,76 @@
+package container
+
+import (
+    "reflect"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+type greeterModule struct{}
+
+func (m *greeterModule) Register(c *Container) error {
+    return c.Register("greeter", &plainGreeter{}, Singleton)
+}
+
+type notifierModule struct{}
+
+func (m *notifierModule) Register(c *Container) error {
+    if err := c.Register("email", &emailNotifier{}, Singleton); err != nil {
+        return err
+    }
+    return c.Register("sms", &smsNotifier{}, Singleton)
+}
+
+func TestContainer_AutoRegister(t *testing.T) {
+    container := NewContainer()
+    require.NoError(t, container.AutoRegister(&greeterModule{}, &notifierModule{}))
+
+    for _, qualifier := range []string{"greeter", "email", "sms"} {
+        _, err := container.Resolve(qualifier)
+        assert.NoError(t, err, qualifier)
+    }
+
+    // Registering a module twice fails on the duplicate qualifier
+    err := container.AutoRegister(&greeterModule{})
+    assert.ErrorContains(t, err, "auto-registration of *container.greeterModule failed: service already registered")
+
+    assert.Error(t, container.AutoRegister(nil))
+}
+
+func TestContainer_AutoBind(t *testing.T) {
+    container := NewContainer()
+    email := &emailNotifier{}
+    add := &addCommand{}
+    service := &testServiceImpl{name: "bound"}
+    require.NoError(t, container.AutoBind(email, add, service))
+
+    tests := []struct {
+        qualifier string
+        iface     reflect.Type
+        expected  interface{}
+    }{
+        {qualifier: "emailNotifier", iface: reflect.TypeOf((*notifier)(nil)).Elem(), expected: email},
+        {qualifier: "addCommand", iface: reflect.TypeOf((*commandHandler)(nil)).Elem(), expected: add},
+        {qualifier: "testServiceImpl", iface: reflect.TypeOf((*TestService)(nil)).Elem(), expected: service},
+    }
+    for _, tt := range tests {
+        t.Run(tt.qualifier, func(t *testing.T) {
+            byQualifier, err := container.Resolve(tt.qualifier)
+            require.NoError(t, err)
+            assert.Same(t, tt.expected, byQualifier)
+
+            byType, err := container.ResolveByType(tt.iface)
+            require.NoError(t, err)
+            assert.Same(t, tt.expected, byType)
+        })
+    }
+
+    // Exported type names are lowercased too
+    require.NoError(t, container.AutoBind(&TestStruct{}))
+    assert.True(t, container.Has("testStruct"))
+
+    assert.ErrorContains(t, container.AutoBind(&emailNotifier{}), "service already registered for qualifier: emailNotifier")
+    assert.ErrorContains(t, container.AutoBind(&struct{ Name string }{}), "has no name to derive a qualifier from")
+}
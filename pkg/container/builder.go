@@ -0,0 +1,97 @@
+// pkg/container/builder.go
+package container
+
+import (
+    "fmt"
+)
+
+// ServiceBuilder collects the settings of one registration for Container.Define.
+// Nothing is registered until Build is called.
+type ServiceBuilder struct {
+    container *Container
+    qualifier string
+    instance  interface{}
+    factory   interface{}
+    scope     Scope
+    primary   bool
+    groups    []string
+    meta      map[string]string
+}
+
+// Define starts a fluent registration of qualifier, e.g.
+//
+//     err := c.Define("userRepo").Instance(repo).Primary().Group("repositories").Build()
+//
+// Exactly one of Instance or Factory must be given; the scope defaults to Singleton.
+func (c *Container) Define(qualifier string) *ServiceBuilder {
+    return &ServiceBuilder{
+        container: c,
+        qualifier: qualifier,
+        scope:     Singleton,
+    }
+}
+
+// Instance registers svc itself, as Register does
+func (b *ServiceBuilder) Instance(svc interface{}) *ServiceBuilder {
+    b.instance = svc
+    return b
+}
+
+// Factory builds the service with fn, which accepts the same forms as RegisterConstructor.
+// Singletons and weak singletons are built on first resolve.
+func (b *ServiceBuilder) Factory(fn interface{}) *ServiceBuilder {
+    b.factory = fn
+    return b
+}
+
+// Scope sets the scope of the service
+func (b *ServiceBuilder) Scope(scope Scope) *ServiceBuilder {
+    b.scope = scope
+    return b
+}
+
+// Primary marks the service as the primary candidate for type-based resolution
+func (b *ServiceBuilder) Primary() *ServiceBuilder {
+    b.primary = true
+    return b
+}
+
+// Group adds the service to a named group, see FindByGroup. It may be called repeatedly.
+func (b *ServiceBuilder) Group(group string) *ServiceBuilder {
+    b.groups = append(b.groups, group)
+    return b
+}
+
+// Meta attaches a metadata label, see FindByMeta
+func (b *ServiceBuilder) Meta(key, value string) *ServiceBuilder {
+    if b.meta == nil {
+        b.meta = make(map[string]string)
+    }
+    b.meta[key] = value
+    return b
+}
+
+// Build registers the service with everything configured on the builder
+func (b *ServiceBuilder) Build() error {
+    c := b.container
+    if (b.instance == nil) == (b.factory == nil) {
+        c.log.Errorw("Service definition needs exactly one of Instance or Factory", "qualifier", b.qualifier)
+        return fmt.Errorf("service definition %s needs exactly one of Instance or Factory", b.qualifier)
+    }
+
+    configure := func(s *ScopedService) {
+        s.Primary = b.primary
+        s.Groups = append([]string(nil), b.groups...)
+        if b.meta != nil {
+            s.Metadata = make(map[string]string, len(b.meta))
+            for key, value := range b.meta {
+                s.Metadata[key] = value
+            }
+        }
+    }
+
+    if b.factory != nil {
+        return c.registerConstructor(b.qualifier, b.factory, b.scope, configure)
+    }
+    return c.register(b.qualifier, b.instance, b.scope, configure)
+}
@@ -0,0 +1,62 @@
+package container
+
+import (
+    "reflect"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+func TestContainer_Define(t *testing.T) {
+    container := NewContainer()
+    email := &emailNotifier{}
+
+    err := container.Define("email").
+        Instance(email).
+        Scope(Singleton).
+        Primary().
+        Group("notifiers").
+        Meta("channel", "mail").
+        Build()
+    require.NoError(t, err)
+    err = container.Define("sms").
+        Factory(func() *smsNotifier { return &smsNotifier{} }).
+        Group("notifiers").
+        Build()
+    require.NoError(t, err)
+
+    service := container.services["email"]
+    assert.Equal(t, Singleton, service.Scope)
+    assert.True(t, service.Primary)
+    assert.Same(t, email, service.Instance)
+
+    assert.Equal(t, []string{"email", "sms"}, container.FindByGroup("notifiers"))
+    assert.Equal(t, []string{"email"}, container.FindByMeta("channel", "mail"))
+
+    // Both match the notifier type; the primary one wins
+    resolved, err := container.ResolveByType(reflect.TypeOf((*notifier)(nil)).Elem())
+    require.NoError(t, err)
+    assert.Same(t, email, resolved)
+
+    // The factory-built singleton is lazy and then cached
+    assert.Nil(t, container.services["sms"].Instance)
+    first, err := container.Resolve("sms")
+    require.NoError(t, err)
+    second, err := container.Resolve("sms")
+    require.NoError(t, err)
+    assert.Same(t, first, second)
+}
+
+func TestContainer_DefineErrors(t *testing.T) {
+    container := NewContainer()
+
+    assert.ErrorContains(t, container.Define("none").Build(), "needs exactly one of Instance or Factory")
+    err := container.Define("both").Instance(&emailNotifier{}).Factory(func() *smsNotifier { return nil }).Build()
+    assert.ErrorContains(t, err, "needs exactly one of Instance or Factory")
+
+    require.NoError(t, container.Define("email").Instance(&emailNotifier{}).Build())
+    err = container.Define("email").Instance(&emailNotifier{}).Build()
+    assert.ErrorContains(t, err, "service already registered for qualifier: email")
+    assert.ErrorContains(t, container.Define("").Factory(func() int { return 1 }).Build(), "must not be empty")
+}
@@ -0,0 +1,115 @@
+// pkg/container/collection.go
+package container
+
+import (
+    "errors"
+    "fmt"
+    "reflect"
+    "sort"
+    "strings"
+)
+
+// allQualifier is the di tag value that asks for every service matching a slice's element type
+const allQualifier = "all"
+
+// prefixTag starts a di tag asking for every service whose qualifier has the given prefix
+const prefixTag = "prefix:"
+
+// resolveAll resolves every service in this container whose registered type is assignable
+// to the element type of sliceType and returns them as a slice of that type, in registration order.
+// Services without a known type (factories returning interface{}, such as RegisterPrototypeFactory)
+// and inactive conditional services are skipped.
+func (c *Container) resolveAll(sliceType reflect.Type) (reflect.Value, error) {
+    elemType := sliceType.Elem()
+
+    c.mu.RLock()
+    type candidate struct {
+        qualifier string
+        service   *ScopedService
+    }
+    candidates := make([]candidate, 0)
+    for qualifier, service := range c.services {
+        if service.Type != nil && service.Type.AssignableTo(elemType) {
+            candidates = append(candidates, candidate{qualifier, service})
+        }
+    }
+    c.mu.RUnlock()
+
+    sort.Slice(candidates, func(i, j int) bool {
+        return candidates[i].service.order < candidates[j].service.order
+    })
+
+    result := reflect.MakeSlice(sliceType, 0, len(candidates))
+    for _, candidate := range candidates {
+        if candidate.service.Condition != nil && !candidate.service.Condition.Matches(c) {
+            continue
+        }
+        instance, err := c.Resolve(candidate.qualifier)
+        if err != nil {
+            return reflect.Value{}, fmt.Errorf("failed to resolve %s: %w", candidate.qualifier, err)
+        }
+        result = reflect.Append(result, reflect.ValueOf(instance))
+    }
+
+    c.log.Debugw("Collected services by type",
+        "type", elemType,
+        "count", result.Len())
+    return result, nil
+}
+
+// resolvePrefix resolves every service in this container whose qualifier starts with prefix
+// and returns them as a map of mapType keyed by the qualifier without the prefix.
+// Inactive conditional services are skipped; an instance not assignable to the map's
+// element type is an error.
+func (c *Container) resolvePrefix(prefix string, mapType reflect.Type) (reflect.Value, error) {
+    elemType := mapType.Elem()
+
+    c.mu.RLock()
+    matches := make(map[string]*ScopedService)
+    for qualifier, service := range c.services {
+        if strings.HasPrefix(qualifier, prefix) && qualifier != prefix {
+            matches[qualifier] = service
+        }
+    }
+    c.mu.RUnlock()
+
+    result := reflect.MakeMapWithSize(mapType, len(matches))
+    for qualifier, service := range matches {
+        if service.Condition != nil && !service.Condition.Matches(c) {
+            continue
+        }
+        instance, err := c.Resolve(qualifier)
+        if err != nil {
+            return reflect.Value{}, fmt.Errorf("failed to resolve %s: %w", qualifier, err)
+        }
+        instanceValue := reflect.ValueOf(instance)
+        if !instanceValue.Type().AssignableTo(elemType) {
+            return reflect.Value{}, fmt.Errorf("service %s of type %v is not assignable to %v",
+                qualifier, instanceValue.Type(), elemType)
+        }
+        key := reflect.ValueOf(strings.TrimPrefix(qualifier, prefix)).Convert(mapType.Key())
+        result.SetMapIndex(key, instanceValue)
+    }
+
+    c.log.Debugw("Collected services by qualifier prefix",
+        "prefix", prefix,
+        "count", result.Len())
+    return result, nil
+}
+
+// ResolveMany resolves each qualifier and returns the instances keyed by qualifier.
+// Every qualifier is attempted: the map holds the services that resolved and the error joins
+// the failures of the others, so startup wiring can check a single error.
+func ResolveMany(c Resolver, qualifiers ...string) (map[string]interface{}, error) {
+    resolved := make(map[string]interface{}, len(qualifiers))
+    var errs []error
+    for _, qualifier := range qualifiers {
+        instance, err := c.Resolve(qualifier)
+        if err != nil {
+            errs = append(errs, fmt.Errorf("resolve %s: %w", qualifier, err))
+            continue
+        }
+        resolved[qualifier] = instance
+    }
+    return resolved, errors.Join(errs...)
+}
@@ -0,0 +1,118 @@
+package container
+
+import (
+    "reflect"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+type notifier interface {
+    Notify(message string) string
+}
+
+type emailNotifier struct{}
+
+func (n *emailNotifier) Notify(message string) string { return "email: " + message }
+
+type smsNotifier struct{}
+
+func (n *smsNotifier) Notify(message string) string { return "sms: " + message }
+
+type broadcaster struct {
+    Notifiers []notifier `di:"all"`
+}
+
+type requiredBroadcaster struct {
+    Notifiers []notifier `di:"all" required:"true"`
+}
+
+func TestContainer_InjectAllOfType(t *testing.T) {
+    container := NewContainer()
+    email := &emailNotifier{}
+    sms := &smsNotifier{}
+    // Registered in the opposite of alphabetical order to prove registration order is kept
+    require.NoError(t, container.Register("sms", sms, Singleton))
+    require.NoError(t, container.Register("email", email, Singleton))
+    require.NoError(t, container.Register("unrelated", &TestStruct{}, Singleton))
+
+    target := &broadcaster{}
+    require.NoError(t, container.InjectStruct(target))
+
+    require.Len(t, target.Notifiers, 2)
+    assert.Same(t, sms, target.Notifiers[0])
+    assert.Same(t, email, target.Notifiers[1])
+}
+
+func TestContainer_InjectAllOfTypeEmpty(t *testing.T) {
+    container := NewContainer()
+
+    optional := &broadcaster{}
+    require.NoError(t, container.InjectStruct(optional))
+    assert.Empty(t, optional.Notifiers)
+
+    err := container.InjectStruct(&requiredBroadcaster{})
+    assert.ErrorContains(t, err, "no services of type container.notifier found")
+}
+
+func TestTagDependencies_SkipsAllSlices(t *testing.T) {
+    assert.Empty(t, tagDependencies(reflect.TypeOf(&broadcaster{})))
+}
+
+type commandHandler interface {
+    Handle(args []string) error
+}
+
+type addCommand struct{}
+
+func (c *addCommand) Handle(args []string) error { return nil }
+
+type removeCommand struct{}
+
+func (c *removeCommand) Handle(args []string) error { return nil }
+
+type commandRouter struct {
+    Handlers map[string]commandHandler `di:"prefix:cmd."`
+}
+
+func TestContainer_InjectByQualifierPrefix(t *testing.T) {
+    container := NewContainer()
+    add := &addCommand{}
+    remove := &removeCommand{}
+    require.NoError(t, container.Register("cmd.add", add, Singleton))
+    require.NoError(t, container.Register("cmd.remove", remove, Singleton))
+    require.NoError(t, container.Register("query.list", &addCommand{}, Singleton))
+
+    router := &commandRouter{}
+    require.NoError(t, container.InjectStruct(router))
+    assert.Equal(t, map[string]commandHandler{"add": add, "remove": remove}, router.Handlers)
+    assert.Empty(t, tagDependencies(reflect.TypeOf(router)))
+
+    // Every service under the prefix must fit the map's element type
+    require.NoError(t, container.Register("cmd.broken", &TestStruct{}, Singleton))
+    err := container.InjectStruct(&commandRouter{})
+    assert.ErrorContains(t, err, "service cmd.broken of type *container.TestStruct is not assignable to container.commandHandler")
+}
+
+func TestResolveMany(t *testing.T) {
+    container := NewContainer()
+    email := &emailNotifier{}
+    sms := &smsNotifier{}
+    service := &testServiceImpl{name: "service"}
+    require.NoError(t, container.Register("email", email, Singleton))
+    require.NoError(t, container.Register("sms", sms, Singleton))
+    require.NoError(t, container.Register("testService", service, Singleton))
+
+    resolved, err := ResolveMany(container, "email", "missing", "sms", "testService")
+    assert.EqualError(t, err, "resolve missing: no service found for qualifier: missing")
+    assert.Equal(t, map[string]interface{}{
+        "email":       email,
+        "sms":         sms,
+        "testService": service,
+    }, resolved)
+
+    resolved, err = ResolveMany(container, "email")
+    require.NoError(t, err)
+    assert.Len(t, resolved, 1)
+}
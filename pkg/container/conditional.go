@@ -0,0 +1,86 @@
+// pkg/container/conditional.go
+package container
+
+import (
+    "fmt"
+)
+
+// RegisterConditional registers a service that is only resolvable while condition matches.
+// The condition is evaluated on every resolve, so it follows later profile changes:
+//   - a singleton is built (and post-constructed) on the first resolve while the condition matches
+//   - when SetActiveProfiles makes the condition false, the cached singleton is pre-destroyed and
+//     dropped, and a later matching resolve builds it again
+//   - while the condition is false the qualifier behaves as unregistered and parents are consulted
+func (c *Container) RegisterConditional(qualifier string, service interface{}, scope Scope, condition Condition) error {
+    if condition == nil {
+        c.log.Errorw("Cannot register conditional service without condition", "qualifier", qualifier)
+        return fmt.Errorf("cannot register conditional service %s with nil condition", qualifier)
+    }
+
+    return c.register(qualifier, service, scope, func(s *ScopedService) {
+        s.Condition = condition
+        s.lazy = true
+    })
+}
+
+// ConditionFunc adapts an ordinary predicate to the Condition interface
+type ConditionFunc func(container *Container) bool
+
+// Matches calls f(container)
+func (f ConditionFunc) Matches(container *Container) bool {
+    return f(container)
+}
+
+// RegisterIf is shorthand for RegisterConditional with an inline predicate. Like any condition,
+// predicate is evaluated at resolve time, so it can react to profiles, other registrations or
+// the environment.
+func (c *Container) RegisterIf(qualifier string, service interface{}, scope Scope, predicate func(*Container) bool) error {
+    if predicate == nil {
+        c.log.Errorw("Cannot register conditional service without predicate", "qualifier", qualifier)
+        return fmt.Errorf("cannot register conditional service %s with nil predicate", qualifier)
+    }
+    return c.RegisterConditional(qualifier, service, scope, ConditionFunc(predicate))
+}
+
+// reevaluateConditions drops cached conditional singletons whose condition no longer matches
+func (c *Container) reevaluateConditions() {
+    type candidate struct {
+        qualifier string
+        service   *ScopedService
+    }
+
+    c.mu.RLock()
+    candidates := make([]candidate, 0)
+    for qualifier, service := range c.services {
+        if service.Condition != nil && service.Scope.caches() && service.Instance != nil {
+            candidates = append(candidates, candidate{qualifier: qualifier, service: service})
+        }
+    }
+    c.mu.RUnlock()
+
+    // Conditions may call back into the container, so evaluate them without holding the lock
+    for _, candidate := range candidates {
+        if candidate.service.Condition.Matches(c) {
+            continue
+        }
+
+        c.mu.Lock()
+        instance := candidate.service.destroyable()
+        external := candidate.service.external
+        candidate.service.Instance = nil
+        candidate.service.undecorated = nil
+        // A rebuilt instance is owned by this container, unless Factory hands out the shared one again
+        candidate.service.external = external && candidate.service.shared
+        c.mu.Unlock()
+
+        if instance == nil || external {
+            continue
+        }
+        c.log.Infow("Deactivating conditional service", "qualifier", candidate.qualifier)
+        if err := c.preDestroy(candidate.qualifier, candidate.service.Scope, instance); err != nil {
+            c.log.Errorw("Failed to destroy deactivated service",
+                "qualifier", candidate.qualifier,
+                "error", err)
+        }
+    }
+}
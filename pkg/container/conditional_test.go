@@ -0,0 +1,105 @@
+package container
+
+import (
+    "os"
+    "testing"
+    "time"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+func TestContainer_ConditionalProfileReevaluation(t *testing.T) {
+    container := NewContainer()
+    container.SetActiveProfiles("prod")
+
+    service := &testServiceImpl{name: "debug"}
+    err := container.RegisterConditional("debugService", service, Singleton, &ProfileCondition{ProfileName: "dev"})
+    require.NoError(t, err)
+
+    // Not available and not constructed while the condition is false
+    _, err = container.Resolve("debugService")
+    assert.Error(t, err)
+    assert.False(t, service.initialized)
+
+    // Switching profiles makes the service resolvable
+    container.SetActiveProfiles("dev")
+    resolved, err := container.Resolve("debugService")
+    require.NoError(t, err)
+    assert.Same(t, service, resolved)
+    assert.True(t, service.initialized)
+
+    // Switching back destroys the cached instance and hides the service again
+    container.SetActiveProfiles("prod")
+    assert.True(t, service.destroyed)
+    _, err = container.Resolve("debugService")
+    assert.Error(t, err)
+}
+
+func TestContainer_ConditionalFallsBackToParent(t *testing.T) {
+    parent := NewContainer()
+    child := NewContainer()
+    child.SetParent(parent)
+
+    parentService := &testServiceImpl{name: "parent"}
+    require.NoError(t, parent.Register("cache", parentService, Singleton))
+    require.NoError(t, child.RegisterConditional("cache", &testServiceImpl{name: "child"}, Singleton, &ProfileCondition{ProfileName: "dev"}))
+
+    // The inactive child binding is skipped in favour of the parent's
+    resolved, err := child.Resolve("cache")
+    require.NoError(t, err)
+    assert.Same(t, parentService, resolved)
+
+    assert.Error(t, child.RegisterConditional("nilCondition", &testServiceImpl{}, Singleton, nil))
+}
+
+func TestContainer_RegisterIf(t *testing.T) {
+    const envVar = "DI_EXTENDED_FEATURE_CACHE"
+    container := NewContainer()
+    err := container.RegisterIf("featureCache", &testServiceImpl{name: "cache"}, Prototype, func(*Container) bool {
+        return os.Getenv(envVar) != ""
+    })
+    require.NoError(t, err)
+
+    t.Setenv(envVar, "")
+    _, err = container.Resolve("featureCache")
+    assert.Error(t, err, "predicate is false while the variable is unset")
+
+    // The predicate runs on every resolve, so setting the variable activates the service
+    t.Setenv(envVar, "on")
+    resolved, err := container.Resolve("featureCache")
+    require.NoError(t, err)
+    assert.Equal(t, "cache", resolved.(*testServiceImpl).name)
+
+    assert.Error(t, container.RegisterIf("nilPredicate", &testServiceImpl{}, Singleton, nil))
+}
+
+func TestContainer_ConditionalBuildCallsBackIntoContainer(t *testing.T) {
+    container := NewContainer()
+    require.NoError(t, container.Register("config", &TestStruct{}, Singleton))
+    require.NoError(t, container.RegisterIf("report", &testServiceImpl{name: "report"}, Singleton,
+        func(*Container) bool { return true }))
+    require.NoError(t, container.Register("cache", &testServiceImpl{name: "cache"}, WeakSingleton))
+
+    // Post-processors of lazily built services run without the container lock
+    container.AddPostProcessor(func(qualifier string, instance interface{}) (interface{}, error) {
+        if _, err := container.Resolve("config"); err != nil {
+            return nil, err
+        }
+        return instance, nil
+    })
+
+    for _, qualifier := range []string{"report", "cache"} {
+        done := make(chan error, 1)
+        go func() {
+            _, err := container.Resolve(qualifier)
+            done <- err
+        }()
+        select {
+        case err := <-done:
+            assert.NoError(t, err, qualifier)
+        case <-time.After(time.Second):
+            t.Fatalf("resolving %s deadlocked", qualifier)
+        }
+    }
+}
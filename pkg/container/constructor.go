@@ -0,0 +1,99 @@
+// pkg/container/constructor.go
+package container
+
+import (
+    "fmt"
+    "reflect"
+)
+
+// emptyInterfaceType is the type of interface{}, which says nothing about what a factory builds
+var emptyInterfaceType = reflect.TypeOf((*interface{})(nil)).Elem()
+
+// RegisterConstructor registers a service built by constructor, a function without parameters
+// returning a value or (value, error), e.g. func() (*sql.DB, error) or func() (interface{}, error).
+// Singletons and weak singletons are built on first resolve; prototypes on every resolve.
+// A construction error is returned from Resolve and a failed singleton is not cached,
+// so a later resolve tries again. Constructors run without the container lock, so they may
+// resolve the services they depend on.
+func (c *Container) RegisterConstructor(qualifier string, constructor interface{}, scope Scope) error {
+    return c.registerConstructor(qualifier, constructor, scope, nil)
+}
+
+// registerConstructor implements RegisterConstructor; configure, if non-nil, adjusts the
+// scoped service before it is stored
+func (c *Container) registerConstructor(qualifier string, constructor interface{}, scope Scope, configure func(*ScopedService)) error {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+
+    c.log.Infow("Registering constructor",
+        "qualifier", qualifier,
+        "constructor", reflect.TypeOf(constructor),
+        "scope", scope)
+
+    if err := c.checkMutable("register", qualifier); err != nil {
+        return err
+    }
+    if err := c.validateQualifier(qualifier); err != nil {
+        return err
+    }
+
+    constructorValue := reflect.ValueOf(constructor)
+    if err := validateConstructor(constructorValue); err != nil {
+        c.log.Errorw("Invalid constructor", "qualifier", qualifier, "error", err)
+        return fmt.Errorf("invalid constructor for qualifier %s: %w", qualifier, err)
+    }
+    if constructorValue.Type().NumIn() != 0 {
+        c.log.Errorw("Constructor must not take parameters", "qualifier", qualifier)
+        return fmt.Errorf("invalid constructor for qualifier %s: must not take parameters, use RegisterDeferred", qualifier)
+    }
+
+    switch scope {
+    case Singleton, WeakSingleton, Prototype:
+    default:
+        c.log.Errorw("Unsupported constructor scope", "qualifier", qualifier, "scope", scope)
+        return fmt.Errorf("unsupported scope for constructor %s: %v", qualifier, scope)
+    }
+
+    if _, exists := c.services[qualifier]; exists {
+        c.log.Errorw("Service already registered", "qualifier", qualifier)
+        return fmt.Errorf("service already registered for qualifier: %s", qualifier)
+    }
+
+    construct := func() (interface{}, error) {
+        return callConstructor(constructorValue, nil)
+    }
+    var serviceType reflect.Type
+    if out := constructorValue.Type().Out(0); out != emptyInterfaceType {
+        serviceType = out
+    }
+
+    scopedService := &ScopedService{
+        Scope: scope,
+        Type:  serviceType,
+        Factory: func() interface{} {
+            instance, err := construct()
+            if err != nil {
+                c.log.Errorw("Constructor failed", "qualifier", qualifier, "error", err)
+                return nil
+            }
+            return instance
+        },
+        Dependencies: mergeDependencies(nil, tagDependencies(serviceType)),
+        lazy:         scope != Prototype,
+        construct:    construct,
+    }
+    if configure != nil {
+        configure(scopedService)
+    }
+    if scope.caches() {
+        c.warnIfMutable(qualifier, serviceType)
+    }
+    c.store(qualifier, scopedService)
+    return nil
+}
+
+// RegisterLazy registers a singleton built by factory on first resolve.
+// factory accepts the same forms as RegisterConstructor.
+func (c *Container) RegisterLazy(qualifier string, factory interface{}) error {
+    return c.RegisterConstructor(qualifier, factory, Singleton)
+}
@@ -0,0 +1,111 @@
+package container
+
+import (
+    "errors"
+    "reflect"
+    "testing"
+    "time"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+func TestContainer_RegisterLazyError(t *testing.T) {
+    container := NewContainer()
+    attempts := 0
+    require.NoError(t, container.RegisterLazy("connection", func() (*testServiceImpl, error) {
+        attempts++
+        if attempts == 1 {
+            return nil, errors.New("connection refused")
+        }
+        return &testServiceImpl{name: "connection"}, nil
+    }))
+
+    _, err := container.Resolve("connection")
+    assert.EqualError(t, err, "failed to construct connection: connection refused")
+
+    // The failure was not cached, so a retry builds the singleton
+    first, err := container.Resolve("connection")
+    require.NoError(t, err)
+    assert.True(t, first.(*testServiceImpl).initialized)
+    second, err := container.Resolve("connection")
+    require.NoError(t, err)
+    assert.Same(t, first, second)
+    assert.Equal(t, 2, attempts)
+
+    // The concrete return type is used for type-based resolution
+    byType, err := container.ResolveByType(reflect.TypeOf(&testServiceImpl{}))
+    require.NoError(t, err)
+    assert.Same(t, first, byType)
+}
+
+func TestContainer_RegisterLazyResolvesDependency(t *testing.T) {
+    container := NewContainer()
+    require.NoError(t, container.RegisterLazy("b", func() *TestStruct {
+        return &TestStruct{}
+    }))
+    require.NoError(t, container.RegisterLazy("a", func() (*testServiceImpl, error) {
+        if _, err := container.Resolve("b"); err != nil {
+            return nil, err
+        }
+        return &testServiceImpl{name: "a"}, nil
+    }))
+    require.NoError(t, container.Define("c").Factory(func() (*testServiceImpl, error) {
+        if _, err := container.Resolve("a"); err != nil {
+            return nil, err
+        }
+        return &testServiceImpl{name: "c"}, nil
+    }).Build())
+
+    // Constructors run without the container lock, so they may resolve other services
+    done := make(chan error, 1)
+    go func() {
+        _, err := container.Resolve("c")
+        done <- err
+    }()
+    select {
+    case err := <-done:
+        require.NoError(t, err)
+    case <-time.After(time.Second):
+        t.Fatal("lazy constructor resolving another service deadlocked")
+    }
+
+    a, err := container.Resolve("a")
+    require.NoError(t, err)
+    assert.True(t, a.(*testServiceImpl).initialized)
+}
+
+func TestContainer_RegisterConstructor(t *testing.T) {
+    container := NewContainer()
+    require.NoError(t, container.RegisterConstructor("prototype", func() (interface{}, error) {
+        return &TestStruct{}, nil
+    }, Prototype))
+    require.NoError(t, container.RegisterConstructor("failing", func() (interface{}, error) {
+        return nil, errors.New("boom")
+    }, Prototype))
+
+    first, err := container.Resolve("prototype")
+    require.NoError(t, err)
+    second, err := container.Resolve("prototype")
+    require.NoError(t, err)
+    assert.NotSame(t, first, second)
+
+    _, err = container.Resolve("failing")
+    assert.EqualError(t, err, "failed to construct failing: boom")
+
+    tests := []struct {
+        name        string
+        constructor interface{}
+        scope       Scope
+    }{
+        {name: "not a function", constructor: 42, scope: Singleton},
+        {name: "takes parameters", constructor: func(int) *TestStruct { return nil }, scope: Singleton},
+        {name: "wrong results", constructor: func() (int, int) { return 0, 0 }, scope: Singleton},
+        {name: "unsupported scope", constructor: func() *TestStruct { return nil }, scope: Request},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            assert.Error(t, container.RegisterConstructor("invalid", tt.constructor, tt.scope))
+        })
+    }
+}
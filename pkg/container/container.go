@@ -2,9 +2,13 @@
 package container
 
 import (
+    "errors"
     "fmt"
     "reflect"
+    "regexp"
+    "strings"
     "sync"
+    "sync/atomic"
     "di-extended/pkg/logger"
     "di-extended/pkg/aop"
     "go.uber.org/zap"
@@ -19,11 +23,35 @@ type Container struct {
     profileManager   *ProfileManager
     aspectManager    *aop.AspectManager
     parent          *Container
+    id              uint64 // Process-unique id used in logs to tell containers apart
+    deferred        []*deferredService // Constructors waiting for Start
+    registrations   uint64             // Number of services stored so far, used to order them
+    tracer          Tracer             // Optional tracer for resolve and aspect spans
+    postProcessors  []PostProcessor    // Applied in order to every newly constructed instance
+    requiredByDefault bool             // Treat di fields without a required tag as required
+    allowConvertible  bool             // Fall back to reflect conversion for non-assignable injections
+    fallbackResolver  FallbackResolver // Supplies services that are registered nowhere in the hierarchy
+    skipNonZero       bool             // Leave fields that already hold a value untouched
+    qualifierPattern  *regexp.Regexp   // Strict mode: qualifiers must match when set
+    metrics           atomic.Pointer[containerMetrics] // Set by RegisterMetrics
+    properties        map[string]string                // Values for ${name} placeholders in di tags
+    propertyWatchers  map[string][]func(string)        // Callbacks fired by SetProperty, see WatchProperty
+    warnMutable       bool                             // Warn about exported mutable fields of singletons
+    frozen            bool                             // Set by Freeze; registrations are rejected
+    scopeStrategies   map[Scope]ScopeStrategy          // Custom scopes added by RegisterScope
+    pools             map[string]*instancePool         // Pooled prototypes enabled by EnablePool
+    aspectProxies     bool                             // Inject aspect proxies, see EnableAspectProxies
+    proxyFactories    map[reflect.Type]proxyFactory    // Proxy builders per interface, see RegisterProxy
+    profileVariants   map[string][]string              // Profiles with a variant per qualifier, see RegisterProfileVariant
 }
 
+// containerIDs hands out ids to containers in creation order
+var containerIDs uint64
+
 // NewContainer creates and initializes a new DI container
 func NewContainer() *Container {
     return &Container{
+        id:               atomic.AddUint64(&containerIDs, 1),
         services:         make(map[string]*ScopedService),
         log:             logger.Get(),
         lifecycleManager: NewLifecycleManager(),
@@ -37,6 +65,11 @@ func NewContainer() *Container {
 
 // Register adds a new service to the container with the specified qualifier and scope
 func (c *Container) Register(qualifier string, service interface{}, scope Scope) error {
+    return c.register(qualifier, service, scope, nil)
+}
+
+// register stores a service; configure, when set, can adjust the scoped service before it is stored
+func (c *Container) register(qualifier string, service interface{}, scope Scope, configure func(*ScopedService)) error {
     c.mu.Lock()
     defer c.mu.Unlock()
 
@@ -45,100 +78,439 @@ func (c *Container) Register(qualifier string, service interface{}, scope Scope)
         "type", reflect.TypeOf(service),
         "scope", scope)
 
+    if err := c.checkMutable("register", qualifier); err != nil {
+        return err
+    }
+    if err := c.validateQualifier(qualifier); err != nil {
+        return err
+    }
+
     if service == nil {
         c.log.Errorw("Cannot register nil service", "qualifier", qualifier)
         return fmt.Errorf("cannot register nil service for qualifier: %s", qualifier)
     }
 
+    if !allowsScope(service, scope) {
+        allowed := service.(ScopeConstraint).AllowedScopes()
+        c.log.Errorw("Scope not allowed by service",
+            "qualifier", qualifier,
+            "scope", scope,
+            "allowed", allowed)
+        return fmt.Errorf("service %s of type %T cannot be registered as %s, allowed scopes: %v",
+            qualifier, service, scope, allowed)
+    }
+
     if _, exists := c.services[qualifier]; exists {
         c.log.Errorw("Service already registered", "qualifier", qualifier)
         return fmt.Errorf("service already registered for qualifier: %s", qualifier)
     }
 
+    _, custom := customScopeName(scope)
+    if custom {
+        if _, ok := c.scopeStrategies[scope]; !ok {
+            c.log.Errorw("Custom scope not registered in this container", "qualifier", qualifier, "scope", scope)
+            return fmt.Errorf("cannot register %s: scope %s is not registered, use RegisterScope", qualifier, scope)
+        }
+    }
+
+    factory := func() interface{} { return service }
+    shared := true
+    if scope == Prototype || scope == WeakSingleton || custom {
+        if scope == Prototype {
+            c.log.Warnw("Registering a static instance as a prototype is deprecated, use RegisterPrototypeFactory",
+                "qualifier", qualifier,
+                "type", reflect.TypeOf(service))
+        }
+        // Every instance is a copy of the registered template, so rebuilding yields a new object
+        copyFactory, err := copyingFactory(service)
+        if err != nil {
+            c.log.Errorw("Cannot register static instance", "qualifier", qualifier, "scope", scope, "error", err)
+            return fmt.Errorf("cannot register %s %s: %w", scope, qualifier, err)
+        }
+        factory = copyFactory
+        shared = false
+    }
+
     // Create scoped service
     scopedService := &ScopedService{
         Scope:        scope,
-        Factory:      func() interface{} { return service },
-        Dependencies: make([]string, 0),
+        Type:         reflect.TypeOf(service),
+        Factory:      factory,
+        Dependencies: mergeDependencies(nil, tagDependencies(reflect.TypeOf(service))),
+        shared:       shared,
+    }
+    if scope == WeakSingleton {
+        scopedService.lazy = true
+    }
+    if configure != nil {
+        configure(scopedService)
+    }
+    if scope.caches() {
+        c.warnIfMutable(qualifier, scopedService.Type)
     }
 
     // Handle singleton scope initialization
-    if scope == Singleton {
+    if scope == Singleton && !scopedService.lazy && scopedService.external {
         scopedService.Instance = service
-        if lifecycleAware, ok := service.(LifecycleAware); ok {
-            // Execute post-construct hooks
-            for _, hook := range c.lifecycleManager.postConstructHooks {
-                if err := hook.Handler(service); err != nil {
-                    return fmt.Errorf("post-construct hook failed: %w", err)
-                }
-            }
-            if err := lifecycleAware.PostConstruct(); err != nil {
-                return fmt.Errorf("post-construct failed: %w", err)
-            }
+    } else if scope == Singleton && !scopedService.lazy {
+        scopedService.profiles = c.profileManager.Active()
+        if err := c.postConstruct(service, scope); err != nil {
+            return err
         }
+        processed, err := c.postProcess(qualifier, service, c.postProcessors)
+        if err != nil {
+            return err
+        }
+        scopedService.Instance = processed
+        scopedService.Type = reflect.TypeOf(processed)
     }
 
+    c.store(qualifier, scopedService)
+    return nil
+}
+
+// store binds qualifier to scopedService and stamps it with its registration order, which
+// doubles as the instance id reported by InstanceID. The caller must hold the write lock.
+func (c *Container) store(qualifier string, scopedService *ScopedService) {
+    c.registrations++
+    scopedService.order = c.registrations
+    scopedService.activation = new(sync.Mutex)
     c.services[qualifier] = scopedService
+    c.countMetric(func(m *containerMetrics) Counter { return m.registrations })
+    c.log.Debugw("Stored service",
+        "qualifier", qualifier,
+        "container", c.id,
+        "instanceID", scopedService.order)
+}
+
+// RegisterPrototypeFactory registers a prototype whose instances are built by factory on every resolve.
+// This is the preferred way to register prototypes; registering a static instance with the
+// Prototype scope is deprecated and only copies the instance.
+// The factory's signature does not tell what it builds, so the service has no recorded type and
+// is invisible to ResolveByType and di:"all" fields; use RegisterPrototypeFactoryOf for that.
+func (c *Container) RegisterPrototypeFactory(qualifier string, factory func() interface{}) error {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+
+    c.log.Infow("Registering prototype factory", "qualifier", qualifier)
+
+    if err := c.checkMutable("register", qualifier); err != nil {
+        return err
+    }
+    if err := c.validateQualifier(qualifier); err != nil {
+        return err
+    }
+
+    if factory == nil {
+        c.log.Errorw("Cannot register nil factory", "qualifier", qualifier)
+        return fmt.Errorf("cannot register nil factory for qualifier: %s", qualifier)
+    }
+
+    if _, exists := c.services[qualifier]; exists {
+        c.log.Errorw("Service already registered", "qualifier", qualifier)
+        return fmt.Errorf("service already registered for qualifier: %s", qualifier)
+    }
+
+    c.store(qualifier, &ScopedService{
+        Scope:        Prototype,
+        Factory:      factory,
+        Dependencies: make([]string, 0),
+    })
     return nil
 }
 
 // Resolve retrieves a service from the container by its qualifier
 func (c *Container) Resolve(qualifier string) (interface{}, error) {
-    c.mu.RLock()
-    defer c.mu.RUnlock()
+    instance, _, err := c.ResolveWithScope(qualifier)
+    return instance, err
+}
 
-    c.log.Debugw("Resolving service", "qualifier", qualifier)
+// ResolveWithScope retrieves a service like Resolve and also reports the scope it was
+// registered with in the container that provided it, which may be an ancestor.
+// Callers can tell from it whether they got a shared or a freshly built instance.
+func (c *Container) ResolveWithScope(qualifier string) (interface{}, Scope, error) {
+    _, end := c.startSpan(nil, "di.resolve "+qualifier)
+    instance, scope, err := c.resolve(qualifier, 0, nil)
+    if err != nil && !c.Has(qualifier) {
+        if fallbackInstance, fallbackScope, ok, fallbackErr := c.resolveFallback(qualifier); ok {
+            instance, scope, err = fallbackInstance, fallbackScope, fallbackErr
+        }
+    }
+    if err != nil {
+        c.countMetric(func(m *containerMetrics) Counter { return m.resolutionErrors })
+    }
+    end(err)
+    return instance, scope, err
+}
 
+// ResolveOr retrieves a service like Resolve but returns fallback instead of an error when the
+// service cannot be resolved. The fallback is returned as-is, without any lifecycle handling.
+func (c *Container) ResolveOr(qualifier string, fallback interface{}) interface{} {
+    instance, err := c.Resolve(qualifier)
+    if err != nil {
+        c.log.Debugw("Using fallback for unresolved service", "qualifier", qualifier, "error", err)
+        return fallback
+    }
+    return instance
+}
+
+// resolve looks up qualifier in this container and then its ancestors.
+// depth counts the parent hops taken so far and path records the ids of the visited containers.
+// The lock is only held for the lookup so conditions, factories and parents run without it;
+// in particular a child never holds its lock while its parent's lock is taken, so
+// containers of a hierarchy can be locked in any order without deadlocking.
+func (c *Container) resolve(qualifier string, depth int, path []string) (interface{}, Scope, error) {
+    c.mu.RLock()
     scopedService, exists := c.services[qualifier]
+    parent := c.parent
+    processors := c.postProcessors
+    var instance interface{}
+    if exists {
+        instance = scopedService.Instance
+    }
+    c.mu.RUnlock()
+
+    path = append(append(make([]string, 0, len(path)+1), path...), fmt.Sprintf("container-%d", c.id))
+
+    c.log.Debugw("Resolving service",
+        "qualifier", qualifier,
+        "container", c.id,
+        "depth", depth)
+
+    if exists && scopedService.Condition != nil && !scopedService.Condition.Matches(c) {
+        c.log.Debugw("Conditional service is not active",
+            "qualifier", qualifier,
+            "container", c.id)
+        exists = false
+    }
+
     if !exists {
-        if c.parent != nil {
+        variant, ok, err := c.activeVariant(qualifier)
+        if err != nil {
+            return nil, 0, err
+        }
+        if ok {
+            c.log.Debugw("Resolving profile variant", "qualifier", qualifier, "variant", variant, "container", c.id)
+            return c.resolve(variant, depth, path[:len(path)-1])
+        }
+        if parent != nil {
             c.log.Debugw("Service not found in current container, checking parent",
-                "qualifier", qualifier)
-            return c.parent.Resolve(qualifier)
+                "qualifier", qualifier,
+                "container", c.id,
+                "parent", parent.id,
+                "depth", depth)
+            return parent.resolve(qualifier, depth+1, path)
         }
-        c.log.Errorw("Service not found", "qualifier", qualifier)
-        return nil, fmt.Errorf("no service found for qualifier: %s", qualifier)
+        c.log.Errorw("Service not found",
+            "qualifier", qualifier,
+            "container", c.id,
+            "depth", depth,
+            "path", strings.Join(path, " -> "))
+        return nil, 0, fmt.Errorf("no service found for qualifier: %s", qualifier)
+    }
+
+    if depth > 0 {
+        c.log.Debugw("Resolved service from ancestor container",
+            "qualifier", qualifier,
+            "container", c.id,
+            "depth", depth,
+            "path", strings.Join(path, " -> "))
     }
 
     c.log.Debugw("Found service",
         "qualifier", qualifier,
+        "container", c.id,
+        "instanceID", scopedService.order,
         "scope", scopedService.Scope)
 
+    resolved, err := c.instantiate(qualifier, scopedService, instance, processors)
+    return resolved, scopedService.Scope, err
+}
+
+// instantiate returns the instance of a found service according to its scope: the cached
+// instance of a singleton, building it first if it is lazy, or a new prototype instance
+func (c *Container) instantiate(qualifier string, scopedService *ScopedService, instance interface{}, processors []PostProcessor) (interface{}, error) {
     switch scopedService.Scope {
-    case Singleton:
-        if scopedService.Instance == nil {
+    case Singleton, WeakSingleton:
+        if instance == nil {
+            if scopedService.lazy {
+                return c.activate(qualifier, scopedService)
+            }
             c.log.Errorw("Singleton instance is nil", "qualifier", qualifier)
             return nil, fmt.Errorf("singleton instance is nil for qualifier: %s", qualifier)
         }
-        return scopedService.Instance, nil
-    case Prototype:
-        instance := scopedService.Factory()
-        if instance == nil {
-            c.log.Errorw("Factory produced nil instance", "qualifier", qualifier)
-            return nil, fmt.Errorf("factory produced nil instance for qualifier: %s", qualifier)
-        }
-        if lifecycleAware, ok := instance.(LifecycleAware); ok {
-            for _, hook := range c.lifecycleManager.postConstructHooks {
-                if err := hook.Handler(instance); err != nil {
-                    return nil, fmt.Errorf("post-construct hook failed: %w", err)
-                }
-            }
-            if err := lifecycleAware.PostConstruct(); err != nil {
-                return nil, fmt.Errorf("post-construct failed: %w", err)
-            }
+        if isTypedNil(instance) {
+            c.log.Errorw("Singleton instance is a typed nil",
+                "qualifier", qualifier,
+                "type", fmt.Sprintf("%T", instance))
+            return nil, fmt.Errorf("singleton for qualifier %s is registered but holds a typed nil %T", qualifier, instance)
         }
         return instance, nil
+    case Prototype:
+        c.mu.RLock()
+        pool, pooled := c.pools[qualifier]
+        c.mu.RUnlock()
+        if pooled {
+            return c.resolvePooled(qualifier, scopedService, pool, processors)
+        }
+        return c.buildPrototype(qualifier, scopedService, processors)
     default:
-        c.log.Errorw("Unsupported scope",
+        return c.resolveCustom(qualifier, scopedService, processors)
+    }
+}
+
+// activate builds a lazy singleton on first resolve and caches it.
+// Builds of the same service are serialized by its own mutex rather than the container lock,
+// so factories, PostConstruct, hooks, post-processors and decorators may call back into the container.
+func (c *Container) activate(qualifier string, scopedService *ScopedService) (interface{}, error) {
+    scopedService.activation.Lock()
+    defer scopedService.activation.Unlock()
+
+    // Another resolve may have built it while we waited for the mutex
+    c.mu.RLock()
+    instance := scopedService.Instance
+    processors := c.postProcessors
+    decorators := scopedService.decorators
+    external := scopedService.external
+    c.mu.RUnlock()
+    if instance != nil {
+        return instance, nil
+    }
+
+    c.log.Infow("Activating singleton", "qualifier", qualifier)
+    // Taken before the build, so a profile change during it is seen as a change later
+    profiles := c.profileManager.Active()
+    var managed interface{}
+    var err error
+    if external {
+        // The instance is shared with the container it was imported from, which owns its lifecycle
+        managed, err = scopedService.create()
+    } else {
+        managed, err = c.build(qualifier, scopedService.Scope, scopedService.create, processors, nil)
+    }
+    if err != nil {
+        return nil, err
+    }
+    // The undecorated instance is kept so PreDestroy runs on the object PostConstruct ran on
+    instance, err = c.decorate(qualifier, managed, decorators)
+    if err != nil {
+        return nil, err
+    }
+
+    c.mu.Lock()
+    scopedService.Instance = instance
+    scopedService.profiles = profiles
+    scopedService.undecorated = nil
+    if len(decorators) > 0 {
+        scopedService.undecorated = managed
+    }
+    c.mu.Unlock()
+    return instance, nil
+}
+
+// build calls create, runs post-construct for scope on the new instance, applies the post-processors
+// and finally the decorators, so the lifecycle runs on the undecorated instance.
+// A panic in any of them is recovered and returned as an error naming the qualifier,
+// so one broken factory cannot crash the caller.
+func (c *Container) build(qualifier string, scope Scope, create func() (interface{}, error), processors []PostProcessor, decorators []func(interface{}) interface{}) (instance interface{}, err error) {
+    defer func() {
+        if r := recover(); r != nil {
+            c.log.Errorw("Factory panicked", "qualifier", qualifier, "panic", r)
+            instance, err = nil, fmt.Errorf("factory for qualifier %s panicked: %v", qualifier, r)
+        }
+    }()
+
+    instance, err = create()
+    if err != nil {
+        c.log.Errorw("Factory failed", "qualifier", qualifier, "error", err)
+        return nil, fmt.Errorf("failed to construct %s: %w", qualifier, err)
+    }
+    if instance == nil {
+        c.log.Errorw("Factory produced nil instance", "qualifier", qualifier)
+        return nil, fmt.Errorf("factory produced nil instance for qualifier: %s", qualifier)
+    }
+    if isTypedNil(instance) {
+        c.log.Errorw("Factory produced typed nil instance",
             "qualifier", qualifier,
-            "scope", scopedService.Scope)
-        return nil, fmt.Errorf("unsupported scope: %v", scopedService.Scope)
+            "type", fmt.Sprintf("%T", instance))
+        return nil, fmt.Errorf("factory for qualifier %s produced a typed nil %T", qualifier, instance)
     }
+    if err := c.postConstruct(instance, scope); err != nil {
+        return nil, err
+    }
+    instance, err = c.postProcess(qualifier, instance, processors)
+    if err != nil {
+        return nil, err
+    }
+    return c.decorate(qualifier, instance, decorators)
 }
 
-// InjectStruct injects dependencies into struct fields marked with "di" tags
-// InjectStruct injects dependencies into struct fields marked with "di" tags
+// postConstruct hands the active profiles to a ProfileAware instance, then runs the
+// post-construct hooks that apply to scope and PostConstruct of a lifecycle-aware instance, or the conventional
+// init method of any other instance
+func (c *Container) postConstruct(instance interface{}, scope Scope) error {
+    if profileAware, ok := instance.(ProfileAware); ok {
+        profileAware.SetProfiles(c.profileManager.Active())
+    }
+    lifecycleAware, ok := instance.(LifecycleAware)
+    if !ok {
+        if err := c.callLifecycleMethod(instance, c.lifecycleManager.InitMethodName()); err != nil {
+            return fmt.Errorf("post-construct failed: %w", err)
+        }
+        return nil
+    }
+    if err := c.lifecycleManager.runHooks(PostConstructPhase, scope, instance); err != nil {
+        return fmt.Errorf("post-construct hook failed: %w", err)
+    }
+    if err := lifecycleAware.PostConstruct(); err != nil {
+        return fmt.Errorf("post-construct failed: %w", err)
+    }
+    return nil
+}
+
+// preDestroy runs the pre-destroy hooks that apply to scope and PreDestroy of a lifecycle-aware instance, or the
+// conventional destroy method of any other instance
+func (c *Container) preDestroy(qualifier string, scope Scope, instance interface{}) error {
+    lifecycleAware, ok := instance.(LifecycleAware)
+    if !ok {
+        if err := c.callLifecycleMethod(instance, c.lifecycleManager.DestroyMethodName()); err != nil {
+            return fmt.Errorf("pre-destroy failed for %s: %w", qualifier, err)
+        }
+        return nil
+    }
+    if err := c.lifecycleManager.runHooks(PreDestroyPhase, scope, instance); err != nil {
+        return fmt.Errorf("pre-destroy hook failed for %s: %w", qualifier, err)
+    }
+    if err := lifecycleAware.PreDestroy(); err != nil {
+        return fmt.Errorf("pre-destroy failed for %s: %w", qualifier, err)
+    }
+    return nil
+}
+
+// InjectStruct injects dependencies into struct fields marked with "di" tags.
+// A slice field tagged di:"all" receives every service assignable to its element type,
+// in registration order. A map with string keys tagged di:"prefix:cmd." receives every service
+// whose qualifier starts with "cmd.", keyed by the rest of the qualifier. Placeholders such as
+// di:"cache-${env}" are substituted from properties or the active profile before resolving.
+// A pointer-to-interface field receives a pointer to the resolved service, or nil when an
+// optional service is absent. A func() T or func() (T, error) field receives a provider that
+// resolves the service when called, which lets services with construction cycles reach each other.
+// With EnableAspectProxies, an interface field receives an aspect proxy of an advised service.
 func (c *Container) InjectStruct(target interface{}) error {
+    return c.injectStruct(target, nil, false)
+}
+
+// InjectStructCollecting injects like InjectStruct but does not stop at the first failing
+// field: every field is attempted, the ones that can be wired are, and all failures are
+// returned joined, so every wiring problem of a struct shows up at once. PostConstruct of a
+// lifecycle-aware target only runs when every field succeeded.
+func (c *Container) InjectStructCollecting(target interface{}) error {
+    return c.injectStruct(target, nil, true)
+}
+
+// injectStruct implements InjectStruct, recording each field decision in trace when non-nil.
+// With collect it attempts every field and returns all failures joined instead of the first.
+func (c *Container) injectStruct(target interface{}, trace *injectionTrace, collect bool) error {
     c.log.Info("Starting struct injection")
 
     targetValue := reflect.ValueOf(target)
@@ -158,129 +530,489 @@ func (c *Container) InjectStruct(target interface{}) error {
         "type", targetType.Name(),
         "numFields", targetType.NumField())
 
-    for i := 0; i < targetType.NumField(); i++ {
-        field := targetType.Field(i)
-        qualifier, ok := field.Tag.Lookup("di")
-        if !ok {
-            c.log.Debugw("Skipping field without di tag", "field", field.Name)
-            continue
+    c.mu.RLock()
+    settings := injectionSettings{
+        requiredByDefault: c.requiredByDefault,
+        allowConvertible:  c.allowConvertible,
+        skipNonZero:       c.skipNonZero,
+        aspectProxies:     c.aspectProxies,
+    }
+    c.mu.RUnlock()
+
+    var errs []error
+    for _, field := range injectionPlanFor(targetType) {
+        if err := c.injectField(targetValue, field, settings, trace); err != nil {
+            if !collect {
+                return err
+            }
+            errs = append(errs, err)
         }
+    }
+    if len(errs) > 0 {
+        c.log.Errorw("Struct injection failed", "type", targetType.Name(), "errors", len(errs))
+        return errors.Join(errs...)
+    }
 
-        c.log.Infow("Processing field for injection",
-            "field", field.Name,
+    // Handle lifecycle
+    if _, ok := target.(LifecycleAware); ok {
+        c.log.Info("Handling lifecycle for injected struct")
+        if err := c.postConstruct(target, noScope); err != nil {
+            c.log.Errorw("Post-construct failed", "error", err)
+            return err
+        }
+    }
+
+    c.log.Info("Completed struct injection")
+    return nil
+}
+
+// injectionSettings are the container options InjectStruct reads once per call
+type injectionSettings struct {
+    requiredByDefault bool
+    allowConvertible  bool
+    skipNonZero       bool
+    aspectProxies     bool
+}
+
+// injectField fills one field of the struct targetValue according to its injection metadata
+func (c *Container) injectField(targetValue reflect.Value, field injectionField, settings injectionSettings, trace *injectionTrace) error {
+    required := field.isRequired(settings.requiredByDefault)
+    if !field.tagged {
+        c.log.Debugw("Skipping field without di tag", "field", field.name)
+        trace.add("skip %s: no di tag", field.name)
+        return nil
+    }
+    qualifier := field.qualifier
+
+    if len(field.profiles) > 0 && !c.anyProfileActive(field.profiles) {
+        c.log.Debugw("Skipping field for inactive profile",
+            "field", field.name,
             "qualifier", qualifier,
-            "required", field.Tag.Get("required"))
+            "profiles", field.profiles)
+        trace.add("skip %s (%s): no active profile of %v", field.name, qualifier, field.profiles)
+        return nil
+    }
 
-        fieldValue := targetValue.Field(i)
-        if !fieldValue.CanSet() {
-            c.log.Warnw("Field cannot be set", "field", field.Name)
-            continue
+    c.log.Infow("Processing field for injection",
+        "field", field.name,
+        "qualifier", qualifier,
+        "required", required)
+
+    fieldValue := targetValue.Field(field.index)
+    if !fieldValue.CanSet() {
+        c.log.Warnw("Field cannot be set", "field", field.name)
+        trace.add("skip %s (%s): unexported", field.name, qualifier)
+        return nil
+    }
+    if settings.skipNonZero && !fieldValue.IsZero() {
+        c.log.Debugw("Skipping field that is already set", "field", field.name, "qualifier", qualifier)
+        trace.add("skip %s (%s): already set", field.name, qualifier)
+        return nil
+    }
+
+    if field.all {
+        collected, err := c.resolveAll(fieldValue.Type())
+        if err != nil {
+            c.log.Errorw("Failed to collect services", "field", field.name, "error", err)
+            return fmt.Errorf("failed to collect services for field %s: %w", field.name, err)
+        }
+        if collected.Len() == 0 && required {
+            c.log.Errorw("No services found for required slice field",
+                "field", field.name,
+                "elementType", fieldValue.Type().Elem())
+            return fmt.Errorf("no services of type %v found for required field %s",
+                fieldValue.Type().Elem(), field.name)
         }
+        fieldValue.Set(collected)
+        c.log.Infow("Successfully injected slice field",
+            "field", field.name,
+            "elementType", fieldValue.Type().Elem(),
+            "count", collected.Len())
+        trace.add("inject %s <- %s (%d services)", field.name, qualifier, collected.Len())
+        return nil
+    }
 
-        service, err := c.Resolve(qualifier)
+    if field.prefix != "" {
+        collected, err := c.resolvePrefix(field.prefix, fieldValue.Type())
         if err != nil {
-            if required, ok := field.Tag.Lookup("required"); ok && required == "true" {
-                c.log.Errorw("Required service not found",
-                    "field", field.Name,
-                    "qualifier", qualifier,
-                    "error", err)
-                return fmt.Errorf("required service not found for field %s: %w", field.Name, err)
+            c.log.Errorw("Failed to collect services", "field", field.name, "prefix", field.prefix, "error", err)
+            return fmt.Errorf("failed to collect services for field %s: %w", field.name, err)
+        }
+        if collected.Len() == 0 && required {
+            c.log.Errorw("No services found for required map field",
+                "field", field.name,
+                "prefix", field.prefix)
+            return fmt.Errorf("no services with qualifier prefix %q found for required field %s",
+                field.prefix, field.name)
+        }
+        fieldValue.Set(collected)
+        c.log.Infow("Successfully injected map field",
+            "field", field.name,
+            "prefix", field.prefix,
+            "count", collected.Len())
+        trace.add("inject %s <- %s (%d services)", field.name, qualifier, collected.Len())
+        return nil
+    }
+
+    if hasPlaceholders(qualifier) {
+        expanded, err := c.expandQualifier(qualifier)
+        if err != nil {
+            return fmt.Errorf("failed to expand qualifier for field %s: %w", field.name, err)
+        }
+        c.log.Debugw("Expanded qualifier", "field", field.name, "qualifier", qualifier, "expanded", expanded)
+        qualifier = expanded
+    }
+
+    if isProviderType(fieldValue.Type()) {
+        // Resolved on each call, so only the registration is checked now
+        if !c.Has(qualifier) {
+            if required {
+                c.log.Errorw("Required service not found for provider", "field", field.name, "qualifier", qualifier)
+                return fmt.Errorf("required service not found for field %s: no service found for qualifier: %s",
+                    field.name, qualifier)
             }
-            c.log.Warnw("Optional service not found",
-                "field", field.Name,
-                "qualifier", qualifier)
-            continue
+            c.log.Warnw("Optional service not found for provider", "field", field.name, "qualifier", qualifier)
+            trace.add("skip %s (%s): optional service not found", field.name, qualifier)
+            return nil
         }
+        fieldValue.Set(c.makeProvider(qualifier, fieldValue.Type()))
+        c.log.Infow("Successfully injected provider field", "field", field.name, "qualifier", qualifier)
+        trace.add("inject %s <- %s (provider)", field.name, qualifier)
+        return nil
+    }
 
-        serviceValue := reflect.ValueOf(service)
-        if !serviceValue.Type().AssignableTo(fieldValue.Type()) {
-            c.log.Errorw("Type mismatch",
-                "field", field.Name,
-                "expectedType", fieldValue.Type(),
-                "actualType", serviceValue.Type())
-            return fmt.Errorf("service type %v is not assignable to field type %v",
-                serviceValue.Type(), fieldValue.Type())
+    service, err := c.Resolve(qualifier)
+    if err != nil {
+        if required {
+            c.log.Errorw("Required service not found",
+                "field", field.name,
+                "qualifier", qualifier,
+                "error", err)
+            return fmt.Errorf("required service not found for field %s: %w", field.name, err)
+        }
+        c.log.Warnw("Optional service not found",
+            "field", field.name,
+            "qualifier", qualifier)
+        if isPointerToInterface(fieldValue.Type()) {
+            // A nil pointer tells callers the collaborator is not configured
+            fieldValue.Set(reflect.Zero(fieldValue.Type()))
         }
+        trace.add("skip %s (%s): optional service not found", field.name, qualifier)
+        return nil
+    }
 
-        fieldValue.Set(serviceValue)
-        c.log.Infow("Successfully injected field",
-            "field", field.Name,
-            "qualifier", qualifier,
-            "type", serviceValue.Type())
+    // A nil service, e.g. left by a decorator or custom scope, would only panic on first use
+    if service == nil || isTypedNil(service) {
+        if required {
+            c.log.Errorw("Required service resolved to nil",
+                "field", field.name,
+                "qualifier", qualifier,
+                "type", fmt.Sprintf("%T", service))
+            return fmt.Errorf("required service %s for field %s resolved to a nil %T",
+                qualifier, field.name, service)
+        }
+        c.log.Warnw("Optional service resolved to nil",
+            "field", field.name,
+            "qualifier", qualifier)
+        trace.add("skip %s (%s): service is nil", field.name, qualifier)
+        return nil
+    }
+    if settings.aspectProxies {
+        service = c.proxyFor(qualifier, service, fieldValue.Type())
     }
 
-    // Handle lifecycle
-    if lifecycleAware, ok := target.(LifecycleAware); ok {
-        c.log.Info("Handling lifecycle for injected struct")
-        for _, hook := range c.lifecycleManager.postConstructHooks {
-            if err := hook.Handler(target); err != nil {
-                c.log.Errorw("Post-construct hook failed", "error", err)
-                return fmt.Errorf("post-construct hook failed: %w", err)
-            }
+    serviceValue := reflect.ValueOf(service)
+    if !serviceValue.Type().AssignableTo(fieldValue.Type()) {
+        if isPointerToInterface(fieldValue.Type()) && serviceValue.Type().AssignableTo(fieldValue.Type().Elem()) {
+            pointer := reflect.New(fieldValue.Type().Elem())
+            pointer.Elem().Set(serviceValue)
+            fieldValue.Set(pointer)
+            c.log.Infow("Successfully injected pointer to interface field",
+                "field", field.name,
+                "qualifier", qualifier,
+                "type", serviceValue.Type())
+            trace.add("inject %s <- %s (pointer)", field.name, qualifier)
+            return nil
         }
-        if err := lifecycleAware.PostConstruct(); err != nil {
-            c.log.Errorw("Post-construct failed", "error", err)
-            return fmt.Errorf("post-construct failed: %w", err)
+        if converted, ok := convertScalar(serviceValue, fieldValue.Type()); ok {
+            fieldValue.Set(converted)
+            c.log.Infow("Successfully injected scalar field",
+                "field", field.name,
+                "qualifier", qualifier,
+                "type", fieldValue.Type())
+            trace.add("inject %s <- %s (scalar)", field.name, qualifier)
+            return nil
         }
+        if settings.allowConvertible {
+            if converted, ok := convertValue(serviceValue, fieldValue.Type()); ok {
+                fieldValue.Set(converted)
+                c.log.Warnw("Injected field by type conversion",
+                    "field", field.name,
+                    "qualifier", qualifier,
+                    "fromType", serviceValue.Type(),
+                    "toType", fieldValue.Type())
+                trace.add("inject %s <- %s (converted)", field.name, qualifier)
+                return nil
+            }
+        }
+        c.log.Errorw("Type mismatch",
+            "field", field.name,
+            "expectedType", fieldValue.Type(),
+            "actualType", serviceValue.Type())
+        return fmt.Errorf("service type %v is not assignable to field type %v",
+            serviceValue.Type(), fieldValue.Type())
     }
 
-    c.log.Info("Completed struct injection")
+    fieldValue.Set(serviceValue)
+    c.log.Infow("Successfully injected field",
+        "field", field.name,
+        "qualifier", qualifier,
+        "type", serviceValue.Type())
+    trace.add("inject %s <- %s", field.name, qualifier)
     return nil
 }
 
-// SetActiveProfiles sets the active profiles
-func (c *Container) SetActiveProfiles(profiles ...string) {
+// isTypedNil reports whether a non-nil interface value wraps a nil pointer, map, slice,
+// func, channel or interface, which a plain == nil comparison does not catch
+func isTypedNil(value interface{}) bool {
+    v := reflect.ValueOf(value)
+    switch v.Kind() {
+    case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.UnsafePointer:
+        return v.IsNil()
+    default:
+        return false
+    }
+}
+
+// SkipNonZeroFields makes InjectStruct leave fields that already hold a non-zero value
+// untouched, e.g. collaborators wired by hand in a test. By default every tagged field is
+// overwritten with the resolved service.
+// It panics on a frozen container.
+func (c *Container) SkipNonZeroFields(enabled bool) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    if err := c.checkMutable("set", "skip non-zero fields"); err != nil {
+        panic(err)
+    }
+    c.skipNonZero = enabled
+    c.log.Infow("Set skip non-zero fields", "enabled", enabled)
+}
+
+// AllowConvertibleInjection lets InjectStruct fill a field whose type the service is not
+// assignable to but can be converted to (reflect ConvertibleTo), logging a warning for each
+// such injection. Disabled by default, so injection requires strict assignability.
+// It panics on a frozen container.
+func (c *Container) AllowConvertibleInjection(enabled bool) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    if err := c.checkMutable("set", "convertible injection"); err != nil {
+        panic(err)
+    }
+    c.allowConvertible = enabled
+    c.log.Infow("Set convertible injection", "enabled", enabled)
+}
+
+// SetRequiredByDefault switches how di fields without a required tag are treated.
+// When enabled they must be resolvable and required:"false" opts a field out;
+// when disabled (the default) they are optional unless tagged required:"true".
+// It panics on a frozen container.
+func (c *Container) SetRequiredByDefault(enabled bool) {
     c.mu.Lock()
     defer c.mu.Unlock()
+    if err := c.checkMutable("set", "required by default"); err != nil {
+        panic(err)
+    }
+    c.requiredByDefault = enabled
+    c.log.Infow("Set required by default", "enabled", enabled)
+}
+
+// copyingFactory returns a factory producing a fresh copy of a statically registered prototype.
+// A prototype with a Clone method returning its own type is copied by calling it. Otherwise the
+// copy is deep (see deepCopy), so every resolve gets a distinct instance that shares no
+// pointers, maps or slices reachable through exported fields with the registered one or with
+// other resolves; unexported fields are copied as they are. Kinds that cannot be copied
+// meaningfully (maps, slices, funcs, channels) and values containing locks such as sync.Mutex
+// are rejected; register a prototype factory or implement Clone for them.
+func copyingFactory(service interface{}) (func() interface{}, error) {
+    prototype := reflect.ValueOf(service)
+    switch prototype.Kind() {
+    case reflect.Ptr:
+        if prototype.IsNil() {
+            return nil, fmt.Errorf("prototype instance is a nil %v", prototype.Type())
+        }
+    case reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.UnsafePointer:
+        return nil, fmt.Errorf("cannot copy a prototype of kind %v, register a factory instead", prototype.Kind())
+    }
+    if clone, ok := cloneMethod(prototype); ok {
+        return func() interface{} {
+            return clone.Call(nil)[0].Interface()
+        }, nil
+    }
+    if _, err := deepCopy(prototype); err != nil {
+        return nil, fmt.Errorf("cannot copy prototype: %w, register a factory or implement Clone instead", err)
+    }
+    return func() interface{} {
+        copied, err := deepCopy(prototype)
+        if err != nil {
+            // Only possible when the prototype was changed after registration; build recovers it
+            panic(err)
+        }
+        return copied.Interface()
+    }, nil
+}
+
+// convertScalar converts a scalar value (bool, number or string) to a field type of the same
+// kind family, so values like an int registered under "retry-count" can fill an int64 field
+func convertScalar(value reflect.Value, target reflect.Type) (reflect.Value, bool) {
+    if scalarFamily(value.Kind()) == "" || scalarFamily(value.Kind()) != scalarFamily(target.Kind()) {
+        return reflect.Value{}, false
+    }
+    if !value.Type().ConvertibleTo(target) {
+        return reflect.Value{}, false
+    }
+    return value.Convert(target), true
+}
+
+// isPointerToInterface reports whether t is a pointer to an interface, e.g. *io.Reader.
+// Such a field holds an optional collaborator: nil when absent, else a pointer to the service.
+func isPointerToInterface(t reflect.Type) bool {
+    return t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Interface
+}
+
+// convertValue converts a non-scalar value to a convertible target type, e.g. a struct to a
+// named type with the same underlying fields. Scalars are left to convertScalar, which
+// refuses conversions such as int to string that reflect allows but change meaning.
+func convertValue(value reflect.Value, target reflect.Type) (converted reflect.Value, ok bool) {
+    if scalarFamily(value.Kind()) != "" || scalarFamily(target.Kind()) != "" {
+        return reflect.Value{}, false
+    }
+    if !value.Type().ConvertibleTo(target) {
+        return reflect.Value{}, false
+    }
+    // Slice to array conversions panic when the slice is too short
+    defer func() {
+        if recover() != nil {
+            converted, ok = reflect.Value{}, false
+        }
+    }()
+    return value.Convert(target), true
+}
+
+// scalarFamily groups kinds that can be converted into each other without changing meaning
+func scalarFamily(kind reflect.Kind) string {
+    switch kind {
+    case reflect.Bool:
+        return "bool"
+    case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+        reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+        reflect.Float32, reflect.Float64:
+        return "number"
+    case reflect.String:
+        return "string"
+    default:
+        return ""
+    }
+}
+
+// SetActiveProfiles sets the active profiles and re-evaluates conditional services.
+// Conditional singletons whose condition no longer matches are destroyed and dropped;
+// services whose condition now matches become resolvable and are built on their next resolve.
+func (c *Container) SetActiveProfiles(profiles ...string) {
+    c.profileManager.SetActive(profiles)
 
-    c.profileManager.active = profiles
     c.log.Infow("Set active profiles", "profiles", profiles)
+    c.reevaluateConditions()
 }
 
-// AddAspect adds an aspect to the container
+// AddAspect adds an aspect to the container. It panics on a frozen container.
 func (c *Container) AddAspect(aspect aop.Aspect) {
     c.mu.Lock()
     defer c.mu.Unlock()
 
+    if err := c.checkMutable("add aspect", fmt.Sprintf("%T", aspect)); err != nil {
+        panic(err)
+    }
+
     c.aspectManager.AddAspect(aspect)
     c.log.Infow("Added aspect",
         "type", fmt.Sprintf("%T", aspect),
         "pointcut", aspect.PointCut())
 }
 
+// AddAdvancedAspect adds an aspect with separate phase methods to the container.
+// Each phase runs at the same point as a simple aspect of the corresponding kind.
+// It panics on a frozen container.
+func (c *Container) AddAdvancedAspect(aspect aop.AdvancedAspect) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+
+    if err := c.checkMutable("add aspect", fmt.Sprintf("%T", aspect)); err != nil {
+        panic(err)
+    }
+
+    c.aspectManager.AddAdvancedAspect(aspect)
+    c.log.Infow("Added advanced aspect",
+        "type", fmt.Sprintf("%T", aspect),
+        "pointcut", aspect.PointCut())
+}
+
+// AddAspectToGroup adds an aspect to a named group, registering it if needed.
+// It panics on a frozen container.
+func (c *Container) AddAspectToGroup(group string, aspect aop.Aspect) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+
+    if err := c.checkMutable("add aspect", fmt.Sprintf("%T", aspect)); err != nil {
+        panic(err)
+    }
+
+    c.aspectManager.AddToGroup(group, aspect)
+    c.log.Infow("Added aspect to group",
+        "group", group,
+        "type", fmt.Sprintf("%T", aspect),
+        "pointcut", aspect.PointCut())
+}
+
+// SetAspectGroupEnabled enables or disables every aspect in a group
+func (c *Container) SetAspectGroupEnabled(group string, enabled bool) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+
+    c.aspectManager.SetGroupEnabled(group, enabled)
+}
+
 // GetLifecycleManager returns the lifecycle manager
 func (c *Container) GetLifecycleManager() *LifecycleManager {
     return c.lifecycleManager
 }
 
-// ExecuteAspects executes all registered aspects for a given join point
+// ExecuteAspects executes all enabled aspects for a given join point in execution order.
+// As in Invoke, AfterReturning advice only runs when jp.Error is nil, and AfterThrowing advice
+// only when jp.Error is set and, for aspects with an aop.ErrorTypeFilter, matches the filtered
+// error type.
 func (c *Container) ExecuteAspects(jp *aop.JoinPoint) error {
     c.mu.RLock()
-    defer c.mu.RUnlock()
+    aspects := c.aspectManager.GetOrderedAspects()
+    c.mu.RUnlock()
 
-    for _, aspect := range c.aspectManager.GetAspects() {
+    // Advice runs without the lock so it may call back into the container
+    for _, aspect := range aspects {
         switch aspect.Kind() {
-        case aop.Before:
-            if err := aspect.Advice(jp); err != nil {
-                return fmt.Errorf("before aspect failed: %w", err)
-            }
-        case aop.After:
-            if err := aspect.Advice(jp); err != nil {
-                return fmt.Errorf("after aspect failed: %w", err)
-            }
-        case aop.Around:
-            if err := aspect.Advice(jp); err != nil {
-                return fmt.Errorf("around aspect failed: %w", err)
-            }
+        case aop.Before, aop.After, aop.Around:
         case aop.AfterReturning:
-            if err := aspect.Advice(jp); err != nil {
-                return fmt.Errorf("after returning aspect failed: %w", err)
+            if jp.Error != nil {
+                continue
             }
         case aop.AfterThrowing:
-            if jp.Error != nil {
-                if err := aspect.Advice(jp); err != nil {
-                    return fmt.Errorf("after throwing aspect failed: %w", err)
-                }
+            if jp.Error == nil || !aop.HandlesError(aspect, jp.Error) {
+                continue
+            }
+        default:
+            continue
+        }
+        if err := c.tracedAdvice(aspect, jp); err != nil {
+            if err := c.adviceError(aspect, jp, err); err != nil {
+                return err
             }
         }
     }
@@ -288,36 +1020,91 @@ func (c *Container) ExecuteAspects(jp *aop.JoinPoint) error {
     return nil
 }
 
-// Cleanup performs cleanup of container resources
+// Cleanup performs cleanup of container resources.
+// Cached instances are destroyed before the services they depend on, based on a topological
+// sort of the recorded dependencies; services unrelated by dependencies, or all services when
+// the dependencies form a cycle, are destroyed in reverse registration order.
+// The instances are collected under the lock and destroyed after releasing it, so PreDestroy
+// code and hooks may call back into the container, e.g. to resolve another service.
+// Custom scope strategies are destroyed last. Prototype pools are discarded and their
+// background refills stopped; later resolves build prototypes on the spot.
 func (c *Container) Cleanup() error {
+    type destroyable struct {
+        qualifier string
+        scope     Scope
+        instance  interface{}
+    }
+
     c.mu.Lock()
-    defer c.mu.Unlock()
+    for qualifier := range c.pools {
+        c.discardPool(qualifier)
+    }
+    order, err := dependencyOrder(c.services)
+    if err != nil {
+        c.log.Warnw("Cannot order cleanup by dependencies, falling back to registration order", "error", err)
+        order = registrationOrder(c.services)
+    }
 
-    for qualifier, service := range c.services {
-        if service.Scope == Singleton && service.Instance != nil {
-            if lifecycleAware, ok := service.Instance.(LifecycleAware); ok {
-                // Execute pre-destroy hooks
-                for _, hook := range c.lifecycleManager.preDestroyHooks {
-                    if err := hook.Handler(service.Instance); err != nil {
-                        return fmt.Errorf("pre-destroy hook failed for %s: %w", qualifier, err)
-                    }
-                }
-                if err := lifecycleAware.PreDestroy(); err != nil {
-                    return fmt.Errorf("pre-destroy failed for %s: %w", qualifier, err)
-                }
-            }
+    // Dependencies come first in the order, so walk it backwards to destroy dependents first
+    pending := make([]destroyable, 0, len(order))
+    for i := len(order) - 1; i >= 0; i-- {
+        qualifier := order[i]
+        service := c.services[qualifier]
+        if service.Scope.caches() && service.Instance != nil && !service.external {
+            pending = append(pending, destroyable{qualifier: qualifier, scope: service.Scope, instance: service.destroyable()})
         }
     }
-    return nil
+    c.mu.Unlock()
+
+    // A failing service does not stop the others from being cleaned up
+    var errs []error
+    for _, item := range pending {
+        if err := c.preDestroy(item.qualifier, item.scope, item.instance); err != nil {
+            c.log.Errorw("Pre-destroy failed", "qualifier", item.qualifier, "error", err)
+            errs = append(errs, err)
+        }
+    }
+    if err := c.destroyScopes(); err != nil {
+        errs = append(errs, err)
+    }
+    return errors.Join(errs...)
+}
+
+// Reset runs Cleanup and then empties the container: services, pools, pending deferred
+// registrations, active profiles and aspects are all cleared so the same instance can be
+// reused, e.g. between test cases. A frozen container is unfrozen. Lifecycle hooks and the
+// parent link are kept.
+// Cleanup errors are returned joined, but the container is reset regardless.
+func (c *Container) Reset() error {
+    err := c.Cleanup()
+
+    c.mu.Lock()
+    defer c.mu.Unlock()
+
+    c.services = make(map[string]*ScopedService)
+    c.deferred = nil
+    for qualifier := range c.pools {
+        c.discardPool(qualifier)
+    }
+    c.profileVariants = nil
+    c.frozen = false
+    c.lifecycleManager.setFrozen(false)
+    c.profileManager.SetActive(nil)
+    c.aspectManager = aop.NewAspectManager()
+
+    c.log.Infow("Container reset", "container", c.id, "cleanupError", err)
+    return err
 }
 
 // Profile management
 func (c *Container) IsProfileActive(profileName string) bool {
-    c.mu.RLock()
-    defer c.mu.RUnlock()
+    return c.profileManager.IsActive(profileName)
+}
 
-    for _, active := range c.profileManager.active {
-        if active == profileName {
+// anyProfileActive reports whether at least one of the given profiles is active
+func (c *Container) anyProfileActive(profiles []string) bool {
+    for _, profile := range profiles {
+        if c.IsProfileActive(profile) {
             return true
         }
     }
@@ -325,8 +1112,12 @@ func (c *Container) IsProfileActive(profileName string) bool {
 }
 
 // SetParent sets the parent container for hierarchical DI
+// It panics on a frozen container.
 func (c *Container) SetParent(parent *Container) {
     c.mu.Lock()
     defer c.mu.Unlock()
+    if err := c.checkMutable("set", "parent"); err != nil {
+        panic(err)
+    }
     c.parent = parent
 }
\ No newline at end of file
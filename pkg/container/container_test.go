@@ -1,11 +1,22 @@
 package container
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"di-extended/pkg/aop"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 // Test structures
@@ -296,4 +307,1048 @@ func TestConcurrency(t *testing.T) {
 
     // Verify all services were registered
     assert.Equal(t, numGoroutines, len(container.services))
-}
\ No newline at end of file
+}
+func TestConcurrentRegisterAndResolve(t *testing.T) {
+    container := NewContainer()
+    const numExisting = 5
+    const numWriters = 4
+    const numReaders = 8
+    const duration = 200 * time.Millisecond
+
+    // Pre-register services the readers will resolve
+    existing := make(map[string]*testServiceImpl, numExisting)
+    for i := 0; i < numExisting; i++ {
+        qualifier := fmt.Sprintf("existing-%d", i)
+        service := &testServiceImpl{name: qualifier}
+        require.NoError(t, container.Register(qualifier, service, Singleton))
+        existing[qualifier] = service
+    }
+
+    deadline := time.Now().Add(duration)
+    var wg sync.WaitGroup
+    var registered int64
+    errs := make(chan error, numWriters+numReaders)
+
+    // Writers keep registering new qualifiers until the deadline
+    for w := 0; w < numWriters; w++ {
+        wg.Add(1)
+        go func(writer int) {
+            defer wg.Done()
+            for n := 0; time.Now().Before(deadline); n++ {
+                qualifier := fmt.Sprintf("writer-%d-%d", writer, n)
+                if err := container.Register(qualifier, &testServiceImpl{name: qualifier}, Singleton); err != nil {
+                    errs <- err
+                    return
+                }
+                atomic.AddInt64(&registered, 1)
+            }
+        }(w)
+    }
+
+    // Readers keep resolving the pre-registered services until the deadline
+    for r := 0; r < numReaders; r++ {
+        wg.Add(1)
+        go func(reader int) {
+            defer wg.Done()
+            for n := 0; time.Now().Before(deadline); n++ {
+                qualifier := fmt.Sprintf("existing-%d", (reader+n)%numExisting)
+                resolved, err := container.Resolve(qualifier)
+                if err != nil {
+                    errs <- err
+                    return
+                }
+                if resolved != existing[qualifier] {
+                    errs <- fmt.Errorf("resolved unexpected instance for %s", qualifier)
+                    return
+                }
+            }
+        }(r)
+    }
+
+    wg.Wait()
+    close(errs)
+    for err := range errs {
+        assert.NoError(t, err)
+    }
+
+    // Every registered service must be resolvable afterwards
+    assert.Equal(t, numExisting+int(atomic.LoadInt64(&registered)), len(container.services))
+    for qualifier := range container.services {
+        _, err := container.Resolve(qualifier)
+        assert.NoError(t, err)
+    }
+}
+
+type recordingAspect struct {
+    kind     aop.AspectKind
+    pointcut string
+    err      error
+    soft     bool
+    calls    int
+}
+
+func (a *recordingAspect) Kind() aop.AspectKind { return a.kind }
+func (a *recordingAspect) PointCut() string     { return a.pointcut }
+func (a *recordingAspect) SoftFail() bool       { return a.soft }
+
+func (a *recordingAspect) Advice(jp *aop.JoinPoint) error {
+    a.calls++
+    return a.err
+}
+
+func TestContainer_ExecuteAspectsSoftFail(t *testing.T) {
+    container := NewContainer()
+    core, logs := observer.New(zapcore.WarnLevel)
+    container.log = zap.New(core).Sugar()
+
+    soft := &recordingAspect{kind: aop.Before, pointcut: ".*", err: errors.New("metrics backend down"), soft: true}
+    critical := &recordingAspect{kind: aop.Before, pointcut: ".*"}
+    container.AddAspect(soft)
+    container.AddAspect(critical)
+
+    // Soft failure is logged and the chain continues
+    err := container.ExecuteAspects(&aop.JoinPoint{Target: &testServiceImpl{}})
+    require.NoError(t, err)
+    assert.Equal(t, 1, soft.calls)
+    assert.Equal(t, 1, critical.calls)
+
+    entries := logs.FilterMessage("Soft-fail aspect failed, continuing").All()
+    require.Len(t, entries, 1)
+    assert.Equal(t, "metrics backend down", entries[0].ContextMap()["error"])
+    assert.Equal(t, "Before", entries[0].ContextMap()["kind"])
+
+    // Critical failure still aborts the chain
+    critical.err = errors.New("unauthorized")
+    err = container.ExecuteAspects(&aop.JoinPoint{Target: &testServiceImpl{}})
+    assert.EqualError(t, err, "Before aspect failed: unauthorized")
+}
+
+func TestContainer_InjectScalarValues(t *testing.T) {
+    container := NewContainer()
+    require.NoError(t, container.Register("retry-count", 5, Singleton))
+    require.NoError(t, container.Register("timeout-ms", int32(250), Singleton))
+    require.NoError(t, container.Register("server", "smtp.example.com", Singleton))
+    require.NoError(t, container.Register("verbose", true, Singleton))
+
+    type settings struct {
+        RetryCount int    `di:"retry-count"`
+        TimeoutMs  int64  `di:"timeout-ms"`
+        Server     string `di:"server"`
+        Verbose    bool   `di:"verbose"`
+    }
+
+    target := &settings{}
+    require.NoError(t, container.InjectStruct(target))
+    assert.Equal(t, 5, target.RetryCount)
+    assert.Equal(t, int64(250), target.TimeoutMs)
+    assert.Equal(t, "smtp.example.com", target.Server)
+    assert.True(t, target.Verbose)
+
+    // Conversions across kind families are rejected
+    type mismatched struct {
+        Server int `di:"server"`
+    }
+    assert.Error(t, container.InjectStruct(&mismatched{}))
+}
+
+func TestContainer_ParentResolutionLogging(t *testing.T) {
+    core, logs := observer.New(zapcore.DebugLevel)
+    observed := zap.New(core).Sugar()
+
+    parent := NewContainer()
+    parent.log = observed
+    child := NewContainer()
+    child.log = observed
+    child.SetParent(parent)
+
+    require.NoError(t, parent.Register("parentService", &testServiceImpl{name: "parent"}, Singleton))
+
+    _, err := child.Resolve("parentService")
+    require.NoError(t, err)
+
+    entries := logs.FilterMessage("Resolved service from ancestor container").All()
+    require.Len(t, entries, 1)
+    fields := entries[0].ContextMap()
+    assert.Equal(t, "parentService", fields["qualifier"])
+    assert.Equal(t, parent.id, fields["container"])
+    assert.EqualValues(t, 1, fields["depth"])
+    assert.Equal(t, fmt.Sprintf("container-%d -> container-%d", child.id, parent.id), fields["path"])
+}
+
+func TestScopedService_String(t *testing.T) {
+    service := &ScopedService{
+        Instance:     &testServiceImpl{name: "test"},
+        Scope:        Singleton,
+        Factory:      func() interface{} { return nil },
+        Dependencies: []string{"a", "b"},
+    }
+
+    output := service.String()
+    assert.Contains(t, output, "scope=Singleton")
+    assert.Contains(t, output, "type=*container.testServiceImpl")
+    assert.Contains(t, output, "instance=true")
+    assert.Contains(t, output, "factory=true")
+    assert.Contains(t, output, "dependencies=[a, b]")
+}
+
+func TestContainer_Dump(t *testing.T) {
+    container := NewContainer()
+    require.NoError(t, container.Register("singletonService", &testServiceImpl{name: "s"}, Singleton))
+    require.NoError(t, container.Register("prototypeService", &testServiceImpl{name: "p"}, Prototype))
+
+    dump := container.Dump()
+    assert.Contains(t, dump, "2 services")
+    assert.Contains(t, dump, "singletonService: scope=Singleton")
+    assert.Contains(t, dump, "prototypeService: scope=Prototype")
+    assert.Contains(t, dump, "instance=false")
+}
+
+func TestContainer_PrototypeDistinctInstances(t *testing.T) {
+    container := NewContainer()
+    prototype := &testServiceImpl{name: "prototype"}
+    require.NoError(t, container.Register("prototypeService", prototype, Prototype))
+
+    first, err := container.Resolve("prototypeService")
+    require.NoError(t, err)
+    second, err := container.Resolve("prototypeService")
+    require.NoError(t, err)
+
+    // Each resolve returns a new copy of the registered prototype
+    assert.NotSame(t, first, second)
+    assert.NotSame(t, prototype, first)
+    assert.Equal(t, "prototype", first.(*testServiceImpl).name)
+    assert.Equal(t, "prototype", second.(*testServiceImpl).name)
+
+    // Mutating one instance does not affect the others
+    first.(*testServiceImpl).name = "changed"
+    assert.Equal(t, "prototype", second.(*testServiceImpl).name)
+    assert.Equal(t, "prototype", prototype.name)
+}
+
+type prototypeHandle struct {
+    name string
+}
+
+type nestedPrototype struct {
+    Labels   map[string]string
+    Tags     []string
+    Settings map[string][]int
+    Parent   *nestedPrototype
+    Self     *nestedPrototype
+    handle   *prototypeHandle
+}
+
+func TestContainer_PrototypeDeepCopy(t *testing.T) {
+    container := NewContainer()
+    prototype := &nestedPrototype{
+        Labels:   map[string]string{"team": "payments"},
+        Tags:     []string{"a"},
+        Settings: map[string][]int{"retries": {3}},
+        Parent:   &nestedPrototype{Labels: map[string]string{"team": "core"}},
+        handle:   &prototypeHandle{name: "connection"},
+    }
+    prototype.Self = prototype
+    require.NoError(t, container.Register("nested", prototype, Prototype))
+
+    resolved, err := container.Resolve("nested")
+    require.NoError(t, err)
+    first := resolved.(*nestedPrototype)
+
+    // Mutating nested maps, slices and pointers of one copy leaves the prototype untouched
+    first.Labels["team"] = "changed"
+    first.Tags[0] = "changed"
+    first.Settings["retries"][0] = 0
+    first.Parent.Labels["team"] = "changed"
+    assert.Equal(t, "payments", prototype.Labels["team"])
+    assert.Equal(t, "a", prototype.Tags[0])
+    assert.Equal(t, 3, prototype.Settings["retries"][0])
+    assert.Equal(t, "core", prototype.Parent.Labels["team"])
+
+    // Cycles are copied as cycles of the copy
+    assert.Same(t, first, first.Self)
+
+    // Unexported fields are copied as they are, so handles in them are shared
+    assert.Same(t, prototype.handle, first.handle)
+
+    resolved, err = container.Resolve("nested")
+    require.NoError(t, err)
+    assert.Equal(t, "payments", resolved.(*nestedPrototype).Labels["team"])
+}
+
+type timedPrototype struct {
+    Created time.Time
+    Expires *time.Time
+}
+
+func TestContainer_PrototypeCopiesTime(t *testing.T) {
+    container := NewContainer()
+    now := time.Now()
+    expires := now.Add(time.Hour).In(time.UTC)
+    require.NoError(t, container.Register("timed", &timedPrototype{Created: now, Expires: &expires}, Prototype))
+
+    resolved, err := container.Resolve("timed")
+    require.NoError(t, err)
+    copied := resolved.(*timedPrototype)
+
+    // The location is shared rather than cloned, so times stay identical
+    assert.True(t, copied.Created == now)
+    assert.Same(t, time.Local, copied.Created.Location())
+    assert.NotSame(t, &expires, copied.Expires)
+    assert.True(t, *copied.Expires == expires)
+    assert.Same(t, time.UTC, copied.Expires.Location())
+}
+
+type lockedPrototype struct {
+    mu    sync.Mutex
+    Count int
+}
+
+type oncePrototype struct {
+    Init *sync.Once
+}
+
+type cloneablePrototype struct {
+    mu     sync.Mutex
+    Name   string
+    clones *int
+}
+
+func (p *cloneablePrototype) Clone() *cloneablePrototype {
+    *p.clones++
+    return &cloneablePrototype{Name: p.Name, clones: p.clones}
+}
+
+func TestContainer_PrototypeWithLock(t *testing.T) {
+    container := NewContainer()
+
+    // Locks cannot be copied safely, whether held directly or through an exported pointer
+    err := container.Register("locked", &lockedPrototype{}, Prototype)
+    assert.EqualError(t, err, "cannot register Prototype locked: cannot copy prototype: "+
+        "*container.lockedPrototype contains a sync.Mutex, which must not be copied, register a factory or implement Clone instead")
+    err = container.Register("once", &oncePrototype{Init: &sync.Once{}}, Prototype)
+    assert.ErrorContains(t, err, "contains a sync.Once")
+    assert.False(t, container.Has("locked"))
+
+    // A Clone method makes the copies instead
+    clones := 0
+    prototype := &cloneablePrototype{Name: "cloned", clones: &clones}
+    require.NoError(t, container.Register("cloneable", prototype, Prototype))
+    resolved, err := container.Resolve("cloneable")
+    require.NoError(t, err)
+    assert.NotSame(t, prototype, resolved)
+    assert.Equal(t, "cloned", resolved.(*cloneablePrototype).Name)
+    assert.Equal(t, 1, clones)
+}
+
+func TestContainer_PrototypeUncopyable(t *testing.T) {
+    container := NewContainer()
+
+    assert.Error(t, container.Register("mapPrototype", map[string]int{}, Prototype))
+    assert.Error(t, container.Register("funcPrototype", func() {}, Prototype))
+    assert.Error(t, container.Register("nilPrototype", (*testServiceImpl)(nil), Prototype))
+}
+
+func TestContainer_RegisterPrototypeFactory(t *testing.T) {
+    container := NewContainer()
+    created := 0
+    err := container.RegisterPrototypeFactory("prototypeService", func() interface{} {
+        created++
+        return &testServiceImpl{name: fmt.Sprintf("instance-%d", created)}
+    })
+    require.NoError(t, err)
+
+    first, err := container.Resolve("prototypeService")
+    require.NoError(t, err)
+    second, err := container.Resolve("prototypeService")
+    require.NoError(t, err)
+
+    assert.NotSame(t, first, second)
+    assert.Equal(t, "instance-1", first.(*testServiceImpl).name)
+    assert.Equal(t, "instance-2", second.(*testServiceImpl).name)
+    assert.True(t, first.(*testServiceImpl).initialized)
+
+    assert.Error(t, container.RegisterPrototypeFactory("prototypeService", func() interface{} { return nil }))
+    assert.Error(t, container.RegisterPrototypeFactory("nilFactory", nil))
+}
+
+func TestContainer_StaticPrototypeDeprecated(t *testing.T) {
+    container := NewContainer()
+    core, logs := observer.New(zapcore.WarnLevel)
+    container.log = zap.New(core).Sugar()
+
+    require.NoError(t, container.Register("prototypeService", &testServiceImpl{name: "static"}, Prototype))
+
+    entries := logs.FilterMessageSnippet("deprecated").All()
+    require.Len(t, entries, 1)
+    assert.Equal(t, "prototypeService", entries[0].ContextMap()["qualifier"])
+}
+
+func TestContainer_InjectProfileTaggedFields(t *testing.T) {
+    container := NewContainer()
+    service := &testServiceImpl{name: "test"}
+    debugger := &testServiceImpl{name: "debugger"}
+    require.NoError(t, container.Register("testService", service, Singleton))
+    require.NoError(t, container.Register("debugService", debugger, Singleton))
+
+    type profiledStruct struct {
+        Service  TestService `di:"testService"`
+        Debugger TestService `di:"debugService" profile:"dev"`
+        Local    TestService `di:"debugService" profile:"local, dev" required:"true"`
+    }
+
+    container.SetActiveProfiles("prod")
+    target := &profiledStruct{}
+    require.NoError(t, container.InjectStruct(target))
+    assert.Equal(t, service, target.Service)
+    assert.Nil(t, target.Debugger)
+    assert.Nil(t, target.Local) // required fields are skipped too when their profile is inactive
+
+    container.SetActiveProfiles("dev")
+    target = &profiledStruct{}
+    require.NoError(t, container.InjectStruct(target))
+    assert.Equal(t, debugger, target.Debugger)
+    assert.Equal(t, debugger, target.Local)
+}
+
+type failingDestroyService struct {
+    testServiceImpl
+}
+
+func (f *failingDestroyService) PreDestroy() error {
+    f.destroyed = true
+    return errors.New("close failed")
+}
+
+func TestContainer_Reset(t *testing.T) {
+    container := NewContainer()
+    first := &testServiceImpl{name: "first"}
+    second := &testServiceImpl{name: "second"}
+    failing := &failingDestroyService{testServiceImpl: testServiceImpl{name: "failing"}}
+    require.NoError(t, container.Register("first", first, Singleton))
+    require.NoError(t, container.Register("second", second, Singleton))
+    require.NoError(t, container.Register("failing", failing, Singleton))
+    container.SetActiveProfiles("dev")
+    container.AddAspect(&recordingAspect{kind: aop.Before, pointcut: ".*"})
+
+    err := container.Reset()
+    assert.ErrorContains(t, err, "pre-destroy failed for failing: close failed")
+
+    // Every singleton was destroyed despite the failure
+    assert.True(t, first.destroyed)
+    assert.True(t, second.destroyed)
+    assert.True(t, failing.destroyed)
+
+    // The container is empty and reusable
+    assert.Empty(t, container.services)
+    assert.False(t, container.IsProfileActive("dev"))
+    assert.Empty(t, container.AspectSummary())
+    require.NoError(t, container.Register("first", &testServiceImpl{name: "again"}, Singleton))
+}
+
+type flushingService struct {
+    testServiceImpl
+    container *Container
+    flushedTo interface{}
+}
+
+// PreDestroy calls back into the container, which must not deadlock during Cleanup
+func (f *flushingService) PreDestroy() error {
+    f.destroyed = true
+    sink, err := f.container.Resolve("sink")
+    f.flushedTo = sink
+    return err
+}
+
+func TestContainer_CleanupCallsBackIntoContainer(t *testing.T) {
+    container := NewContainer()
+    sink := &testServiceImpl{name: "sink"}
+    flushing := &flushingService{container: container}
+    require.NoError(t, container.Register("sink", sink, Singleton))
+    require.NoError(t, container.Register("flushing", flushing, Singleton))
+
+    done := make(chan error, 1)
+    go func() { done <- container.Cleanup() }()
+    select {
+    case err := <-done:
+        require.NoError(t, err)
+    case <-time.After(2 * time.Second):
+        t.Fatal("Cleanup deadlocked on a PreDestroy that resolves a service")
+    }
+
+    assert.True(t, flushing.destroyed)
+    assert.True(t, sink.destroyed)
+    assert.Same(t, sink, flushing.flushedTo)
+}
+
+type statefulService struct {
+    counter int
+}
+
+func (s *statefulService) AllowedScopes() []Scope { return []Scope{Singleton} }
+
+func TestContainer_RegisterScopeConstraint(t *testing.T) {
+    container := NewContainer()
+
+    err := container.Register("stateful", &statefulService{}, Prototype)
+    assert.ErrorContains(t, err, "cannot be registered as Prototype, allowed scopes: [Singleton]")
+    _, err = container.Resolve("stateful")
+    assert.Error(t, err)
+
+    require.NoError(t, container.Register("stateful", &statefulService{}, Singleton))
+}
+
+type panickingService struct {
+    testServiceImpl
+}
+
+func (p *panickingService) PostConstruct() error {
+    panic("post-construct exploded")
+}
+
+func TestContainer_ResolvePanickingFactory(t *testing.T) {
+    container := NewContainer()
+    require.NoError(t, container.RegisterPrototypeFactory("broken", func() interface{} {
+        panic("factory exploded")
+    }))
+    require.NoError(t, container.RegisterPrototypeFactory("brokenInit", func() interface{} {
+        return &panickingService{}
+    }))
+
+    tests := []struct {
+        qualifier string
+        message   string
+    }{
+        {qualifier: "broken", message: "factory for qualifier broken panicked: factory exploded"},
+        {qualifier: "brokenInit", message: "factory for qualifier brokenInit panicked: post-construct exploded"},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.qualifier, func(t *testing.T) {
+            var (
+                resolved interface{}
+                err      error
+            )
+            assert.NotPanics(t, func() {
+                resolved, err = container.Resolve(tt.qualifier)
+            })
+            assert.Nil(t, resolved)
+            assert.EqualError(t, err, tt.message)
+        })
+    }
+}
+
+func TestContainer_ResolveOr(t *testing.T) {
+    container := NewContainer()
+    initialized := 0
+    container.GetLifecycleManager().AddPostConstructHook(LifecycleHook{
+        Name:    "count",
+        Handler: func(interface{}) error { initialized++; return nil },
+    })
+    real := &testServiceImpl{name: "real"}
+    require.NoError(t, container.Register("present", real, Singleton))
+    initialized = 0
+
+    fallback := &testServiceImpl{name: "fallback"}
+    assert.Same(t, fallback, container.ResolveOr("missing", fallback))
+    assert.False(t, fallback.initialized, "the fallback is not run through lifecycle hooks")
+    assert.Zero(t, initialized)
+
+    assert.Same(t, real, container.ResolveOr("present", fallback))
+    assert.Nil(t, container.ResolveOr("missing", nil))
+}
+
+func TestContainer_ResolveTypedNilSingleton(t *testing.T) {
+    container := NewContainer()
+    // Stored directly so no lifecycle method is called on the nil pointer
+    container.services["typedNil"] = &ScopedService{
+        Instance: (*testServiceImpl)(nil),
+        Scope:    Singleton,
+    }
+    require.NoError(t, container.RegisterPrototypeFactory("nilFactory", func() interface{} {
+        return (*TestStruct)(nil)
+    }))
+
+    _, err := container.Resolve("typedNil")
+    assert.EqualError(t, err,
+        "singleton for qualifier typedNil is registered but holds a typed nil *container.testServiceImpl")
+
+    _, err = container.Resolve("nilFactory")
+    assert.EqualError(t, err, "factory for qualifier nilFactory produced a typed nil *container.TestStruct")
+
+    // A missing service still reports as not found
+    _, err = container.Resolve("absent")
+    assert.EqualError(t, err, "no service found for qualifier: absent")
+}
+
+type profileRecorder struct {
+    testServiceImpl
+    profiles        []string
+    profilesAtStart []string
+}
+
+func (p *profileRecorder) SetProfiles(profiles []string) { p.profiles = profiles }
+
+func (p *profileRecorder) PostConstruct() error {
+    p.profilesAtStart = p.profiles
+    return p.testServiceImpl.PostConstruct()
+}
+
+func TestContainer_ProfileAware(t *testing.T) {
+    container := NewContainer()
+    container.SetActiveProfiles("dev")
+    require.NoError(t, container.RegisterPrototypeFactory("recorder", func() interface{} {
+        return &profileRecorder{}
+    }))
+
+    resolved, err := container.ResolveWithProfiles("recorder")
+    require.NoError(t, err)
+    // Profiles were handed over before PostConstruct ran
+    assert.Equal(t, []string{"dev"}, resolved.(*profileRecorder).profilesAtStart)
+}
+
+// profileCounter counts profile handovers and initialisations of a cached service
+type profileCounter struct {
+    testServiceImpl
+    profiles  []string
+    setCalls  int
+    initCalls int
+}
+
+func (p *profileCounter) SetProfiles(profiles []string) {
+    p.profiles = profiles
+    p.setCalls++
+}
+
+func (p *profileCounter) PostConstruct() error {
+    p.initCalls++
+    return p.testServiceImpl.PostConstruct()
+}
+
+func TestContainer_ResolveWithProfilesRefreshesOnChange(t *testing.T) {
+    container := NewContainer()
+    container.SetActiveProfiles("dev", "eu")
+    counter := &profileCounter{}
+    require.NoError(t, container.Register("counter", counter, Singleton))
+    require.Equal(t, 1, counter.setCalls)
+
+    // Unchanged profiles, in any order, leave the shared instance alone
+    _, err := container.ResolveWithProfiles("counter")
+    require.NoError(t, err)
+    container.SetActiveProfiles("eu", "dev")
+    _, err = container.ResolveWithProfiles("counter")
+    require.NoError(t, err)
+    assert.Equal(t, 1, counter.setCalls)
+
+    // A changed profile set is handed over once, without re-running PostConstruct
+    container.SetActiveProfiles("prod")
+    for i := 0; i < 2; i++ {
+        resolved, err := container.ResolveWithProfiles("counter")
+        require.NoError(t, err)
+        assert.Same(t, counter, resolved)
+    }
+    assert.Equal(t, []string{"prod"}, counter.profiles)
+    assert.Equal(t, 2, counter.setCalls)
+    assert.Equal(t, 1, counter.initCalls)
+}
+
+func TestContainer_ResolveWithProfilesFromParent(t *testing.T) {
+    parent := NewContainer()
+    counter := &profileCounter{}
+    require.NoError(t, parent.RegisterLazy("counter", func() *profileCounter { return counter }))
+    _, err := parent.Resolve("counter")
+    require.NoError(t, err)
+
+    child := NewContainer()
+    child.SetParent(parent)
+    child.SetActiveProfiles("test")
+    resolved, err := child.ResolveWithProfiles("counter")
+    require.NoError(t, err)
+    assert.Same(t, counter, resolved)
+    assert.Equal(t, []string{"test"}, counter.profiles)
+    assert.Equal(t, 1, counter.initCalls)
+}
+
+func TestContainer_ResolveWithProfilesDecorated(t *testing.T) {
+    container := NewContainer()
+    counter := &profileCounter{}
+    require.NoError(t, container.Register("counter", counter, Singleton))
+    require.NoError(t, container.Decorate("counter", wrapNamed))
+
+    // The wrapper is returned, the service beneath it gets the profiles
+    container.SetActiveProfiles("dev")
+    resolved, err := container.ResolveWithProfiles("counter")
+    require.NoError(t, err)
+    assert.IsType(t, &namedWrapper{}, resolved)
+    assert.Equal(t, []string{"dev"}, counter.profiles)
+}
+
+type defaultRequirementStruct struct {
+    Unmarked TestService `di:"missingService"`
+    OptedOut TestService `di:"otherMissing" required:"false"`
+}
+
+func TestContainer_SetRequiredByDefault(t *testing.T) {
+    container := NewContainer()
+
+    // Default behaviour: unmarked fields are optional
+    require.NoError(t, container.InjectStruct(&defaultRequirementStruct{}))
+
+    container.SetRequiredByDefault(true)
+    err := container.InjectStruct(&defaultRequirementStruct{})
+    assert.ErrorContains(t, err, "required service not found for field Unmarked")
+
+    // required:"false" opts out, so only the unmarked field is reported by Validate
+    require.NoError(t, container.Register("holder", &defaultRequirementStruct{}, Singleton))
+    err = container.Validate()
+    assert.ErrorContains(t, err, "depends on missing service missingService")
+    assert.NotContains(t, err.Error(), "otherMissing")
+
+    container.SetRequiredByDefault(false)
+    require.NoError(t, container.InjectStruct(&defaultRequirementStruct{}))
+    assert.NoError(t, container.Validate())
+}
+
+type celsius struct {
+    Degrees float64
+}
+
+type temperature struct {
+    Degrees float64
+}
+
+type thermostat struct {
+    Reading temperature `di:"reading" required:"true"`
+}
+
+type labelledThermostat struct {
+    Label string `di:"count" required:"true"`
+}
+
+func TestContainer_AllowConvertibleInjection(t *testing.T) {
+    container := NewContainer()
+    require.NoError(t, container.Register("reading", celsius{Degrees: 21.5}, Singleton))
+    require.NoError(t, container.Register("count", 65, Singleton))
+
+    // Strict assignability by default
+    err := container.InjectStruct(&thermostat{})
+    assert.ErrorContains(t, err, "service type container.celsius is not assignable to field type container.temperature")
+
+    container.AllowConvertibleInjection(true)
+    target := &thermostat{}
+    require.NoError(t, container.InjectStruct(target))
+    assert.Equal(t, 21.5, target.Reading.Degrees)
+
+    // Conversions that change meaning, like int to string, stay rejected
+    assert.Error(t, container.InjectStruct(&labelledThermostat{}))
+}
+
+func TestContainer_WithProfiles(t *testing.T) {
+    container := NewContainer()
+    container.SetActiveProfiles("base")
+
+    err := container.WithProfiles([]string{"test", "local"}, func() error {
+        assert.True(t, container.IsProfileActive("test"))
+        assert.True(t, container.IsProfileActive("local"))
+        assert.False(t, container.IsProfileActive("base"))
+        return errors.New("fn failed")
+    })
+    assert.EqualError(t, err, "fn failed")
+    assert.True(t, container.IsProfileActive("base"))
+    assert.False(t, container.IsProfileActive("test"))
+
+    assert.PanicsWithValue(t, "boom", func() {
+        _ = container.WithProfiles([]string{"test"}, func() error {
+            panic("boom")
+        })
+    })
+    assert.True(t, container.IsProfileActive("base"))
+    assert.False(t, container.IsProfileActive("test"))
+}
+
+func TestContainer_SkipNonZeroFields(t *testing.T) {
+    container := NewContainer()
+    registered := &testServiceImpl{name: "registered"}
+    require.NoError(t, container.Register("testService", registered, Singleton))
+    require.NoError(t, container.Register("optionalService", registered, Singleton))
+    manual := &testServiceImpl{name: "manual"}
+
+    // Default: pre-set fields are overwritten
+    target := &TestStruct{Service: manual}
+    require.NoError(t, container.InjectStruct(target))
+    assert.Same(t, registered, target.Service)
+
+    container.SkipNonZeroFields(true)
+    target = &TestStruct{Service: manual}
+    require.NoError(t, container.InjectStruct(target))
+    assert.Same(t, manual, target.Service, "pre-set field is preserved")
+    assert.Same(t, registered, target.Optional, "zero fields are still injected")
+}
+
+func TestContainer_ResolveFromGrandparentConcurrently(t *testing.T) {
+    grandparent := NewContainer()
+    parent := NewContainer()
+    child := NewContainer()
+    parent.SetParent(grandparent)
+    child.SetParent(parent)
+
+    shared := &testServiceImpl{name: "shared"}
+    require.NoError(t, grandparent.Register("shared", shared, Singleton))
+
+    var wg sync.WaitGroup
+    errs := make(chan error, 200)
+    for i := 0; i < 50; i++ {
+        wg.Add(2)
+        go func() {
+            defer wg.Done()
+            resolved, err := child.Resolve("shared")
+            if err == nil && resolved != shared {
+                err = fmt.Errorf("resolved %v instead of the grandparent's service", resolved)
+            }
+            errs <- err
+        }()
+        go func(i int) {
+            defer wg.Done()
+            errs <- child.Register(fmt.Sprintf("local-%d", i), &TestStruct{}, Singleton)
+            // Writers on the ancestors interleave with the child's resolves too
+            errs <- grandparent.Register(fmt.Sprintf("remote-%d", i), &TestStruct{}, Singleton)
+        }(i)
+    }
+
+    done := make(chan struct{})
+    go func() {
+        wg.Wait()
+        close(done)
+    }()
+    select {
+    case <-done:
+    case <-time.After(5 * time.Second):
+        t.Fatal("resolving through the hierarchy deadlocked")
+    }
+
+    close(errs)
+    for err := range errs {
+        assert.NoError(t, err)
+    }
+}
+
+func TestContainer_ResolveWithScope(t *testing.T) {
+    parent := NewContainer()
+    child := NewContainer()
+    child.SetParent(parent)
+
+    singleton := &testServiceImpl{name: "singleton"}
+    require.NoError(t, child.Register("singleton", singleton, Singleton))
+    require.NoError(t, child.RegisterPrototypeFactory("prototype", func() interface{} {
+        return &TestStruct{}
+    }))
+    require.NoError(t, parent.Register("inherited", &testServiceImpl{}, WeakSingleton))
+
+    tests := []struct {
+        qualifier string
+        scope     Scope
+    }{
+        {qualifier: "singleton", scope: Singleton},
+        {qualifier: "prototype", scope: Prototype},
+        {qualifier: "inherited", scope: WeakSingleton},
+    }
+    for _, tt := range tests {
+        t.Run(tt.qualifier, func(t *testing.T) {
+            instance, scope, err := child.ResolveWithScope(tt.qualifier)
+            require.NoError(t, err)
+            assert.NotNil(t, instance)
+            assert.Equal(t, tt.scope, scope)
+        })
+    }
+
+    _, _, err := child.ResolveWithScope("missing")
+    assert.Error(t, err)
+}
+
+func TestContainer_RegisterQualifierValidation(t *testing.T) {
+    container := NewContainer()
+
+    tests := []struct {
+        name      string
+        qualifier string
+    }{
+        {name: "empty", qualifier: ""},
+        {name: "whitespace", qualifier: "  \t"},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            err := container.Register(tt.qualifier, &TestStruct{}, Singleton)
+            assert.ErrorContains(t, err, "qualifier must not be empty or blank")
+            err = container.RegisterPrototypeFactory(tt.qualifier, func() interface{} { return &TestStruct{} })
+            assert.ErrorContains(t, err, "qualifier must not be empty or blank")
+        })
+    }
+
+    // Strict mode rejects qualifiers that do not match the configured pattern
+    container.SetQualifierPattern(regexp.MustCompile(`^[a-z][A-Za-z0-9]*$`))
+    assert.ErrorContains(t, container.Register("user service", &TestStruct{}, Singleton),
+        `qualifier "user service" does not match pattern`)
+    assert.NoError(t, container.Register("userService", &TestStruct{}, Singleton))
+
+    container.SetQualifierPattern(nil)
+    assert.NoError(t, container.Register("user-service", &TestStruct{}, Singleton))
+}
+
+type profileCacheClient struct {
+    Cache *testServiceImpl `di:"cache-${env}"`
+}
+
+type regionCacheClient struct {
+    Cache *testServiceImpl `di:"cache-${region}-${env}"`
+}
+
+func TestContainer_InjectQualifierPlaceholders(t *testing.T) {
+    container := NewContainer()
+    devCache := &testServiceImpl{name: "dev"}
+    require.NoError(t, container.Register("cache-dev", devCache, Singleton))
+    require.NoError(t, container.Register("cache-prod", &testServiceImpl{name: "prod"}, Singleton))
+    require.NoError(t, container.Register("cache-eu-prod", &testServiceImpl{name: "eu-prod"}, Singleton))
+
+    container.SetActiveProfiles("dev")
+    client := &profileCacheClient{}
+    require.NoError(t, container.InjectStruct(client))
+    assert.Same(t, devCache, client.Cache)
+
+    // ${region} comes from a property; a property named env overrides the profile
+    container.SetProperty("region", "eu")
+    container.SetProperty("env", "prod")
+    regional := &regionCacheClient{}
+    require.NoError(t, container.InjectStruct(regional))
+    assert.Equal(t, "eu-prod", regional.Cache.name)
+
+    assert.Empty(t, tagDependencies(reflect.TypeOf(client)))
+}
+
+func TestContainer_InjectQualifierPlaceholderErrors(t *testing.T) {
+    container := NewContainer()
+
+    err := container.InjectStruct(&profileCacheClient{})
+    assert.ErrorContains(t, err, "placeholder ${env} needs exactly one active profile, got []")
+
+    container.SetActiveProfiles("dev")
+    err = container.InjectStruct(&regionCacheClient{})
+    assert.ErrorContains(t, err, `cannot expand qualifier "cache-${region}-${env}": unresolved placeholder ${region}`)
+
+    container.SetActiveProfiles("dev", "test")
+    err = container.InjectStruct(&profileCacheClient{})
+    assert.ErrorContains(t, err, "needs exactly one active profile, got [dev test]")
+}
+
+type optionalNotifierClient struct {
+    Notifier *notifier `di:"notifier"`
+}
+
+func TestContainer_InjectPointerToInterface(t *testing.T) {
+    container := NewContainer()
+
+    // Absent: the field is reset to nil, distinguishing "not configured"
+    client := &optionalNotifierClient{Notifier: new(notifier)}
+    require.NoError(t, container.InjectStruct(client))
+    assert.True(t, client.Notifier == nil)
+
+    email := &emailNotifier{}
+    require.NoError(t, container.Register("notifier", email, Singleton))
+    require.NoError(t, container.InjectStruct(client))
+    require.NotNil(t, client.Notifier)
+    assert.Same(t, email, *client.Notifier)
+    assert.Equal(t, "email: hi", (*client.Notifier).Notify("hi"))
+}
+
+// nilScope is a faulty custom scope that hands out typed nils, bypassing register-time checks
+type nilScope struct{}
+
+func (nilScope) Get(string, func() interface{}) interface{} {
+    var missing *emailNotifier
+    return missing
+}
+
+func (nilScope) Destroy() {}
+
+type requiredNotifierClient struct {
+    Notifier notifier `di:"notifier" required:"true"`
+}
+
+type optionalNotifierField struct {
+    Notifier notifier `di:"notifier"`
+}
+
+func TestContainer_InjectNilService(t *testing.T) {
+    container := NewContainer()
+    scope, err := container.RegisterScope("nil-notifier", nilScope{})
+    require.NoError(t, err)
+    require.NoError(t, container.Register("notifier", &emailNotifier{}, scope))
+
+    client := &requiredNotifierClient{}
+    err = container.InjectStruct(client)
+    assert.EqualError(t, err, "required service notifier for field Notifier resolved to a nil *container.emailNotifier")
+    assert.True(t, client.Notifier == nil)
+
+    optional := &optionalNotifierField{}
+    require.NoError(t, container.InjectStruct(optional))
+    assert.True(t, optional.Notifier == nil, "the typed nil is not injected")
+}
+
+func TestContainer_WatchProperty(t *testing.T) {
+    container := NewContainer()
+
+    var timeouts, others []string
+    container.WatchProperty("timeout", func(newValue string) { timeouts = append(timeouts, newValue) })
+    container.WatchProperty("region", func(newValue string) { others = append(others, newValue) })
+    container.WatchProperty("timeout", nil)
+
+    container.SetProperty("timeout", "5s")
+    container.SetProperty("timeout", "5s") // unchanged, no callback
+    container.SetProperty("timeout", "10s")
+    assert.Equal(t, []string{"5s", "10s"}, timeouts)
+    assert.Empty(t, others)
+
+    // A watcher may call back into the container
+    container.WatchProperty("timeout", func(newValue string) {
+        container.SetProperty("region", "eu-"+newValue)
+    })
+    container.SetProperty("timeout", "30s")
+    assert.Equal(t, []string{"5s", "10s", "30s"}, timeouts)
+    assert.Equal(t, []string{"eu-30s"}, others)
+}
+
+type partiallyWiredStruct struct {
+    Service  TestService `di:"testService" required:"true"`
+    Missing  TestService `di:"missingService" required:"true"`
+    Port     string      `di:"port"`
+    Optional TestService `di:"optionalService"`
+}
+
+func TestContainer_InjectStructCollecting(t *testing.T) {
+    container := NewContainer()
+    service := &testServiceImpl{}
+    require.NoError(t, container.Register("testService", service, Singleton))
+    require.NoError(t, container.Register("port", 8080, Singleton))
+
+    // InjectStruct stops at the first problem
+    target := &partiallyWiredStruct{}
+    err := container.InjectStruct(target)
+    assert.ErrorContains(t, err, "missingService")
+    assert.NotContains(t, err.Error(), "not assignable")
+
+    target = &partiallyWiredStruct{}
+    err = container.InjectStructCollecting(target)
+    require.Error(t, err)
+    assert.ErrorContains(t, err, "required service not found for field Missing: no service found for qualifier: missingService")
+    assert.ErrorContains(t, err, "service type int is not assignable to field type string")
+    assert.Len(t, strings.Split(err.Error(), "\n"), 2)
+
+    // The fields that could be wired were
+    assert.Same(t, service, target.Service)
+    assert.Nil(t, target.Missing)
+    assert.Empty(t, target.Port)
+    assert.Nil(t, target.Optional)
+
+    // Without problems it behaves like InjectStruct, including PostConstruct
+    lifecycle := &testServiceImpl{}
+    require.NoError(t, container.InjectStructCollecting(lifecycle))
+    assert.True(t, lifecycle.initialized)
+}
@@ -0,0 +1,88 @@
+// pkg/container/convention.go
+package container
+
+import (
+    "fmt"
+    "reflect"
+)
+
+// SetInitMethodName makes the container call a conventionally named method, e.g. "Init", after
+// constructing an instance that does not implement LifecycleAware. The method must take no
+// arguments and return nothing or an error; an error fails the construction like PostConstruct.
+// Instances without such a method are left alone. An empty name turns the convention off.
+// It panics on a frozen container.
+func (c *Container) SetInitMethodName(name string) {
+    c.lifecycleManager.SetInitMethodName(name)
+    c.log.Infow("Set init method name", "name", name)
+}
+
+// SetInitMethodName sets the conventional init method name, see Container.SetInitMethodName.
+// It panics while the owning container is frozen.
+func (lm *LifecycleManager) SetInitMethodName(name string) {
+    lm.mu.Lock()
+    defer lm.mu.Unlock()
+    lm.checkMutable("set init method name")
+    lm.initMethodName = name
+}
+
+// InitMethodName returns the conventional init method name, empty when none is configured
+func (lm *LifecycleManager) InitMethodName() string {
+    lm.mu.Lock()
+    defer lm.mu.Unlock()
+    return lm.initMethodName
+}
+
+// SetDestroyMethodName makes the container call a conventionally named method, e.g. "Close" or
+// "Shutdown", when destroying a cached instance that does not implement LifecycleAware, e.g. in
+// Cleanup. The same signatures as for SetInitMethodName are supported, so io.Closer services
+// qualify. Errors are reported like PreDestroy errors. An empty name turns the convention off.
+// It panics on a frozen container.
+func (c *Container) SetDestroyMethodName(name string) {
+    c.lifecycleManager.SetDestroyMethodName(name)
+    c.log.Infow("Set destroy method name", "name", name)
+}
+
+// SetDestroyMethodName sets the conventional destroy method name, see Container.SetDestroyMethodName.
+// It panics while the owning container is frozen.
+func (lm *LifecycleManager) SetDestroyMethodName(name string) {
+    lm.mu.Lock()
+    defer lm.mu.Unlock()
+    lm.checkMutable("set destroy method name")
+    lm.destroyMethodName = name
+}
+
+// DestroyMethodName returns the conventional destroy method name, empty when none is configured
+func (lm *LifecycleManager) DestroyMethodName() string {
+    lm.mu.Lock()
+    defer lm.mu.Unlock()
+    return lm.destroyMethodName
+}
+
+// callLifecycleMethod calls the method called name on instance if it has one with a supported
+// signature: no arguments, returning nothing or an error
+func (c *Container) callLifecycleMethod(instance interface{}, name string) error {
+    if name == "" {
+        return nil
+    }
+    method := reflect.ValueOf(instance).MethodByName(name)
+    if !method.IsValid() {
+        return nil
+    }
+
+    methodType := method.Type()
+    returnsError := methodType.NumOut() == 1 && methodType.Out(0) == errorType
+    if methodType.NumIn() != 0 || (methodType.NumOut() != 0 && !returnsError) {
+        c.log.Warnw("Ignoring lifecycle method with unsupported signature",
+            "type", fmt.Sprintf("%T", instance),
+            "method", name,
+            "signature", methodType)
+        return nil
+    }
+
+    c.log.Debugw("Calling lifecycle method", "type", fmt.Sprintf("%T", instance), "method", name)
+    results := method.Call(nil)
+    if returnsError && !results[0].IsNil() {
+        return fmt.Errorf("%s failed: %w", name, results[0].Interface().(error))
+    }
+    return nil
+}
@@ -0,0 +1,88 @@
+package container
+
+import (
+    "errors"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+type initService struct {
+    initCalls int
+    initErr   error
+}
+
+func (s *initService) Init() error {
+    s.initCalls++
+    return s.initErr
+}
+
+type plainInitService struct {
+    initCalls int
+}
+
+func (s *plainInitService) Init() { s.initCalls++ }
+
+type unsupportedInitService struct{}
+
+func (s *unsupportedInitService) Init(force bool) error { return errors.New("must not be called") }
+
+func TestContainer_SetInitMethodName(t *testing.T) {
+    container := NewContainer()
+
+    // Off by default
+    untouched := &initService{}
+    require.NoError(t, container.Register("untouched", untouched, Singleton))
+    assert.Zero(t, untouched.initCalls)
+
+    container.SetInitMethodName("Init")
+    service := &initService{}
+    require.NoError(t, container.Register("service", service, Singleton))
+    assert.Equal(t, 1, service.initCalls)
+
+    require.NoError(t, container.RegisterConstructor("lazy", func() *plainInitService { return &plainInitService{} }, Singleton))
+    lazy, err := container.Resolve("lazy")
+    require.NoError(t, err)
+    assert.Equal(t, 1, lazy.(*plainInitService).initCalls)
+
+    require.NoError(t, container.Register("unsupported", &unsupportedInitService{}, Singleton))
+
+    err = container.Register("failing", &initService{initErr: errors.New("not ready")}, Singleton)
+    assert.EqualError(t, err, "post-construct failed: Init failed: not ready")
+}
+
+type closingService struct {
+    closed   bool
+    closeErr error
+}
+
+func (s *closingService) Close() error {
+    s.closed = true
+    return s.closeErr
+}
+
+func TestContainer_SetDestroyMethodName(t *testing.T) {
+    container := NewContainer()
+    container.SetDestroyMethodName("Close")
+
+    database := &closingService{}
+    broker := &closingService{closeErr: errors.New("broker unreachable")}
+    cache := &closingService{closeErr: errors.New("flush failed")}
+    require.NoError(t, container.Register("database", database, Singleton))
+    require.NoError(t, container.Register("broker", broker, Singleton))
+    require.NoError(t, container.Register("cache", cache, Singleton))
+
+    err := container.Cleanup()
+    assert.ErrorContains(t, err, "pre-destroy failed for broker: Close failed: broker unreachable")
+    assert.ErrorContains(t, err, "pre-destroy failed for cache: Close failed: flush failed")
+    assert.True(t, database.closed)
+    assert.True(t, broker.closed)
+    assert.True(t, cache.closed)
+
+    // Off again, Close is not called
+    container.SetDestroyMethodName("")
+    database.closed = false
+    require.NoError(t, container.Cleanup())
+    assert.False(t, database.closed)
+}
@@ -0,0 +1,137 @@
+// pkg/container/customscope.go
+package container
+
+import (
+    "errors"
+    "fmt"
+    "sync"
+)
+
+// ScopeStrategy implements a custom scope registered with Container.RegisterScope.
+// Get returns the instance for qualifier in the scope's current context, calling factory
+// to build one when the context has none yet; Destroy discards every instance it holds.
+type ScopeStrategy interface {
+    Get(qualifier string, factory func() interface{}) interface{}
+    Destroy()
+}
+
+// customScopeBase is the first Scope value handed out to custom scopes
+const customScopeBase Scope = 100
+
+// customScopes maps custom scope names to their Scope values. Names are global so the same
+// name denotes the same Scope in every container and Scope.String can report it.
+var customScopes = struct {
+    sync.RWMutex
+    byName map[string]Scope
+    names  map[Scope]string
+}{byName: make(map[string]Scope), names: make(map[Scope]string)}
+
+// scopeForName returns the Scope value of a custom scope name, allocating one on first use
+func scopeForName(name string) Scope {
+    customScopes.Lock()
+    defer customScopes.Unlock()
+    if scope, ok := customScopes.byName[name]; ok {
+        return scope
+    }
+    scope := customScopeBase + Scope(len(customScopes.byName))
+    customScopes.byName[name] = scope
+    customScopes.names[scope] = name
+    return scope
+}
+
+// customScopeName returns the name of a custom scope, or false for other values
+func customScopeName(scope Scope) (string, bool) {
+    customScopes.RLock()
+    defer customScopes.RUnlock()
+    name, ok := customScopes.names[scope]
+    return name, ok
+}
+
+// RegisterScope adds a custom scope, e.g. "tenant", handled by strategy and returns the Scope
+// to pass to Register. Resolving a service of the scope asks strategy for the instance; new
+// instances are copies of the registered one and go through the normal lifecycle.
+// Cleanup destroys the strategies after pre-destroying the container's cached instances.
+func (c *Container) RegisterScope(name string, strategy ScopeStrategy) (Scope, error) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+
+    if err := c.checkMutable("register scope", name); err != nil {
+        return 0, err
+    }
+    if name == "" || strategy == nil {
+        c.log.Errorw("Invalid custom scope", "name", name)
+        return 0, fmt.Errorf("custom scope needs a name and a strategy, got name %q", name)
+    }
+    for builtin := Singleton; builtin <= WeakSingleton; builtin++ {
+        if builtin.String() == name {
+            c.log.Errorw("Custom scope shadows a built-in scope", "name", name)
+            return 0, fmt.Errorf("custom scope %s shadows a built-in scope", name)
+        }
+    }
+
+    scope := scopeForName(name)
+    if _, exists := c.scopeStrategies[scope]; exists {
+        c.log.Errorw("Custom scope already registered", "name", name)
+        return 0, fmt.Errorf("custom scope already registered: %s", name)
+    }
+    if c.scopeStrategies == nil {
+        c.scopeStrategies = make(map[Scope]ScopeStrategy)
+    }
+    c.scopeStrategies[scope] = strategy
+    c.log.Infow("Registered custom scope", "name", name, "scope", int(scope))
+    return scope, nil
+}
+
+// resolveCustom obtains an instance of a custom-scoped service from its strategy
+func (c *Container) resolveCustom(qualifier string, scopedService *ScopedService, processors []PostProcessor) (interface{}, error) {
+    c.mu.RLock()
+    strategy, ok := c.scopeStrategies[scopedService.Scope]
+    c.mu.RUnlock()
+    if !ok {
+        c.log.Errorw("Unsupported scope",
+            "qualifier", qualifier,
+            "scope", scopedService.Scope)
+        return nil, fmt.Errorf("unsupported scope: %v", scopedService.Scope)
+    }
+
+    // The strategy only sees instances; a failed build is reported through buildErr
+    decorators := c.decoratorsOf(scopedService)
+    var buildErr error
+    instance := strategy.Get(qualifier, func() interface{} {
+        built, err := c.build(qualifier, scopedService.Scope, scopedService.create, processors, decorators)
+        buildErr = err
+        return built
+    })
+    if buildErr != nil {
+        return nil, buildErr
+    }
+    if instance == nil {
+        c.log.Errorw("Custom scope returned nil instance", "qualifier", qualifier, "scope", scopedService.Scope)
+        return nil, fmt.Errorf("scope %v returned nil instance for qualifier: %s", scopedService.Scope, qualifier)
+    }
+    return instance, nil
+}
+
+// destroyScopes calls Destroy on every custom scope strategy, recovering from panics
+func (c *Container) destroyScopes() error {
+    c.mu.RLock()
+    strategies := make(map[Scope]ScopeStrategy, len(c.scopeStrategies))
+    for scope, strategy := range c.scopeStrategies {
+        strategies[scope] = strategy
+    }
+    c.mu.RUnlock()
+
+    var errs []error
+    for scope, strategy := range strategies {
+        func() {
+            defer func() {
+                if r := recover(); r != nil {
+                    c.log.Errorw("Custom scope destroy panicked", "scope", scope, "panic", r)
+                    errs = append(errs, fmt.Errorf("destroy of scope %v panicked: %v", scope, r))
+                }
+            }()
+            strategy.Destroy()
+        }()
+    }
+    return errors.Join(errs...)
+}
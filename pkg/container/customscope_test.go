@@ -0,0 +1,82 @@
+package container
+
+import (
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+// tenantScope keeps one instance per qualifier for the current tenant
+type tenantScope struct {
+    tenant    string
+    instances map[string]interface{}
+    gets      int
+    destroyed bool
+}
+
+func (s *tenantScope) Get(qualifier string, factory func() interface{}) interface{} {
+    s.gets++
+    key := s.tenant + "/" + qualifier
+    if instance, ok := s.instances[key]; ok {
+        return instance
+    }
+    instance := factory()
+    if instance != nil {
+        s.instances[key] = instance
+    }
+    return instance
+}
+
+func (s *tenantScope) Destroy() {
+    s.instances = make(map[string]interface{})
+    s.destroyed = true
+}
+
+func TestContainer_RegisterScope(t *testing.T) {
+    container := NewContainer()
+    scope := &tenantScope{tenant: "acme", instances: make(map[string]interface{})}
+    tenant, err := container.RegisterScope("tenant", scope)
+    require.NoError(t, err)
+    assert.Equal(t, "tenant", tenant.String())
+
+    require.NoError(t, container.Register("settings", &testServiceImpl{name: "settings"}, tenant))
+
+    first, err := container.Resolve("settings")
+    require.NoError(t, err)
+    again, err := container.Resolve("settings")
+    require.NoError(t, err)
+    assert.Same(t, first, again, "the same tenant shares the instance")
+    assert.Equal(t, 2, scope.gets)
+    assert.True(t, first.(*testServiceImpl).initialized, "instances go through the lifecycle")
+
+    scope.tenant = "globex"
+    other, _, err := container.ResolveWithScope("settings")
+    require.NoError(t, err)
+    assert.NotSame(t, first, other, "another tenant gets its own copy")
+
+    require.NoError(t, container.Cleanup())
+    assert.True(t, scope.destroyed)
+}
+
+func TestContainer_RegisterScopeErrors(t *testing.T) {
+    container := NewContainer()
+    strategy := &tenantScope{instances: make(map[string]interface{})}
+
+    _, err := container.RegisterScope("Singleton", strategy)
+    assert.ErrorContains(t, err, "shadows a built-in scope")
+    _, err = container.RegisterScope("", strategy)
+    assert.Error(t, err)
+    _, err = container.RegisterScope("request-batch", nil)
+    assert.Error(t, err)
+
+    batch, err := container.RegisterScope("batch", strategy)
+    require.NoError(t, err)
+    _, err = container.RegisterScope("batch", strategy)
+    assert.ErrorContains(t, err, "custom scope already registered: batch")
+
+    // The scope name is global, but each container needs its own strategy
+    other := NewContainer()
+    err = other.Register("job", &testServiceImpl{}, batch)
+    assert.ErrorContains(t, err, "scope batch is not registered, use RegisterScope")
+}
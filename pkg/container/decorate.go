@@ -0,0 +1,85 @@
+// pkg/container/decorate.go
+package container
+
+import (
+    "fmt"
+    "reflect"
+)
+
+// Decorate wraps the service registered under qualifier with decorator, which receives the
+// current instance and returns its replacement (e.g. a caching wrapper). Decorators chain:
+// each call wraps the result of the previous one, so the last decorator is the outermost.
+// A built singleton is decorated immediately; every instance built afterwards is decorated
+// once PostConstruct, hooks and post-processors have run on it. Lifecycle callbacks therefore
+// see the undecorated instance, and Cleanup runs PreDestroy on it rather than on the wrapper.
+// decorator runs with the container locked when decorating a built instance and must not
+// call back into the container.
+func (c *Container) Decorate(qualifier string, decorator func(inner interface{}) interface{}) error {
+    if decorator == nil {
+        c.log.Errorw("Cannot decorate with nil decorator", "qualifier", qualifier)
+        return fmt.Errorf("cannot decorate %s with a nil decorator", qualifier)
+    }
+
+    c.mu.Lock()
+    defer c.mu.Unlock()
+
+    if err := c.checkMutable("decorate", qualifier); err != nil {
+        return err
+    }
+    scopedService, exists := c.services[qualifier]
+    if !exists {
+        c.log.Errorw("Cannot decorate unknown service", "qualifier", qualifier)
+        return fmt.Errorf("no service found for qualifier: %s", qualifier)
+    }
+
+    if scopedService.Instance != nil {
+        decorated := decorator(scopedService.Instance)
+        if decorated == nil {
+            c.log.Errorw("Decorator returned nil", "qualifier", qualifier)
+            return fmt.Errorf("decorator for %s returned nil", qualifier)
+        }
+        if scopedService.undecorated == nil {
+            scopedService.undecorated = scopedService.Instance
+        }
+        scopedService.Instance = decorated
+        scopedService.Type = reflect.TypeOf(decorated)
+    }
+
+    // Copy on write so builds holding the previous slice are unaffected
+    decorators := make([]func(interface{}) interface{}, len(scopedService.decorators), len(scopedService.decorators)+1)
+    copy(decorators, scopedService.decorators)
+    scopedService.decorators = append(decorators, decorator)
+    c.restartPool(qualifier, scopedService)
+
+    c.log.Infow("Decorated service",
+        "qualifier", qualifier,
+        "scope", scopedService.Scope,
+        "type", scopedService.Type)
+    return nil
+}
+
+// decoratorsOf returns the decorators of a service
+func (c *Container) decoratorsOf(scopedService *ScopedService) []func(interface{}) interface{} {
+    c.mu.RLock()
+    defer c.mu.RUnlock()
+    return scopedService.decorators
+}
+
+// decorate applies decorators in order to a newly built instance, recovering from panics
+func (c *Container) decorate(qualifier string, instance interface{}, decorators []func(interface{}) interface{}) (decorated interface{}, err error) {
+    defer func() {
+        if r := recover(); r != nil {
+            c.log.Errorw("Decorator panicked", "qualifier", qualifier, "panic", r)
+            decorated, err = nil, fmt.Errorf("decorator for qualifier %s panicked: %v", qualifier, r)
+        }
+    }()
+
+    for _, decorator := range decorators {
+        instance = decorator(instance)
+        if instance == nil {
+            c.log.Errorw("Decorator returned nil", "qualifier", qualifier)
+            return nil, fmt.Errorf("decorator for %s returned nil", qualifier)
+        }
+    }
+    return instance, nil
+}
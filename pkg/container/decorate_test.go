@@ -0,0 +1,152 @@
+package container
+
+import (
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+type greeter interface {
+    Greet() string
+}
+
+type plainGreeter struct{}
+
+func (g *plainGreeter) Greet() string { return "hello" }
+
+type wrappingGreeter struct {
+    inner  greeter
+    prefix string
+}
+
+func (g *wrappingGreeter) Greet() string { return g.prefix + g.inner.Greet() }
+
+func wrapWith(prefix string) func(interface{}) interface{} {
+    return func(inner interface{}) interface{} {
+        return &wrappingGreeter{inner: inner.(greeter), prefix: prefix}
+    }
+}
+
+func TestContainer_Decorate(t *testing.T) {
+    tests := []struct {
+        name     string
+        register func(c *Container) error
+    }{
+        {
+            name: "singleton",
+            register: func(c *Container) error {
+                return c.Register("greeter", &plainGreeter{}, Singleton)
+            },
+        },
+        {
+            name: "prototype factory",
+            register: func(c *Container) error {
+                return c.RegisterPrototypeFactory("greeter", func() interface{} { return &plainGreeter{} })
+            },
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            container := NewContainer()
+            require.NoError(t, tt.register(container))
+
+            require.NoError(t, container.Decorate("greeter", wrapWith("cached:")))
+            require.NoError(t, container.Decorate("greeter", wrapWith("logged:")))
+
+            resolved, err := container.Resolve("greeter")
+            require.NoError(t, err)
+
+            // The last decorator is the outermost wrapper
+            outer, ok := resolved.(*wrappingGreeter)
+            require.True(t, ok)
+            assert.Equal(t, "logged:", outer.prefix)
+            assert.IsType(t, &wrappingGreeter{}, outer.inner)
+            assert.Equal(t, "logged:cached:hello", outer.Greet())
+        })
+    }
+}
+
+func TestContainer_DecorateErrors(t *testing.T) {
+    container := NewContainer()
+    require.NoError(t, container.Register("greeter", &plainGreeter{}, Singleton))
+
+    assert.ErrorContains(t, container.Decorate("missing", wrapWith("x")), "no service found")
+    assert.ErrorContains(t, container.Decorate("greeter", nil), "nil decorator")
+    assert.ErrorContains(t, container.Decorate("greeter", func(interface{}) interface{} { return nil }),
+        "returned nil")
+
+    // A failed decoration leaves the binding untouched
+    resolved, err := container.Resolve("greeter")
+    require.NoError(t, err)
+    assert.IsType(t, &plainGreeter{}, resolved)
+}
+
+type namedWrapper struct {
+    inner TestService
+}
+
+func (w *namedWrapper) GetName() string { return "wrapped:" + w.inner.GetName() }
+
+func wrapNamed(inner interface{}) interface{} {
+    return &namedWrapper{inner: inner.(TestService)}
+}
+
+func TestContainer_DecorateKeepsLifecycleOnInner(t *testing.T) {
+    tests := []struct {
+        name     string
+        register func(c *Container) error
+        cached   bool
+    }{
+        {
+            name: "lazy singleton",
+            register: func(c *Container) error {
+                return c.RegisterLazy("service", func() *testServiceImpl { return &testServiceImpl{name: "lazy"} })
+            },
+            cached: true,
+        },
+        {
+            name: "prototype factory",
+            register: func(c *Container) error {
+                return c.RegisterPrototypeFactory("service", func() interface{} { return &testServiceImpl{name: "prototype"} })
+            },
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            container := NewContainer()
+            require.NoError(t, tt.register(container))
+            require.NoError(t, container.Decorate("service", wrapNamed))
+
+            resolved, err := container.Resolve("service")
+            require.NoError(t, err)
+            wrapper, ok := resolved.(*namedWrapper)
+            require.True(t, ok)
+
+            // PostConstruct ran on the inner service before it was wrapped
+            inner := wrapper.inner.(*testServiceImpl)
+            assert.True(t, inner.initialized)
+
+            require.NoError(t, container.Cleanup())
+            assert.Equal(t, tt.cached, inner.destroyed)
+        })
+    }
+}
+
+func TestContainer_DecorateBuiltSingletonDestroysInner(t *testing.T) {
+    container := NewContainer()
+    inner := &testServiceImpl{name: "eager"}
+    require.NoError(t, container.Register("service", inner, Singleton))
+    require.NoError(t, container.Decorate("service", wrapNamed))
+    require.NoError(t, container.Decorate("service", wrapNamed))
+
+    resolved, err := container.Resolve("service")
+    require.NoError(t, err)
+    assert.Equal(t, "wrapped:wrapped:eager", resolved.(TestService).GetName())
+
+    // PreDestroy reaches the undecorated instance, not the outermost wrapper
+    require.NoError(t, container.Cleanup())
+    assert.True(t, inner.destroyed)
+}
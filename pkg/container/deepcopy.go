@@ -0,0 +1,145 @@
+// pkg/container/deepcopy.go
+package container
+
+import (
+    "fmt"
+    "reflect"
+)
+
+// deepCopy returns a recursive copy of value: pointers, maps, slices, arrays, interfaces and
+// the exported fields of structs are copied all the way down, so the copy shares none of that
+// state with the original. Unexported fields are copied as they are without being followed,
+// which keeps values such as time.Time intact and leaves handles held in them shared.
+// Pointers and maps reached more than once are copied once, which keeps aliasing and cycles
+// intact. Funcs, channels and unsafe pointers cannot be copied and are shared.
+// Copying a value that contains a lock such as sync.Mutex or sync.Once fails, since a copied
+// lock no longer guards anything and may be copied mid-use.
+func deepCopy(value reflect.Value) (reflect.Value, error) {
+    copier := &deepCopier{copies: make(map[copyKey]reflect.Value)}
+    copied := copier.copy(value)
+    if copier.lock != nil {
+        return reflect.Value{}, fmt.Errorf("%v contains a %v, which must not be copied", value.Type(), copier.lock)
+    }
+    return copied, nil
+}
+
+// copyKey identifies an already copied pointer or map by address and type
+type copyKey struct {
+    address uintptr
+    t       reflect.Type
+}
+
+// deepCopier tracks the copies made so far during one deepCopy
+type deepCopier struct {
+    copies map[copyKey]reflect.Value
+    lock   reflect.Type // First lock found in a copied struct, which fails the copy
+}
+
+func (d *deepCopier) copy(value reflect.Value) reflect.Value {
+    switch value.Kind() {
+    case reflect.Ptr:
+        if value.IsNil() {
+            return reflect.Zero(value.Type())
+        }
+        key := copyKey{address: value.Pointer(), t: value.Type()}
+        if copied, ok := d.copies[key]; ok {
+            return copied
+        }
+        copied := reflect.New(value.Type().Elem())
+        d.copies[key] = copied
+        copied.Elem().Set(d.copy(value.Elem()))
+        return copied
+    case reflect.Map:
+        if value.IsNil() {
+            return reflect.Zero(value.Type())
+        }
+        key := copyKey{address: value.Pointer(), t: value.Type()}
+        if copied, ok := d.copies[key]; ok {
+            return copied
+        }
+        copied := reflect.MakeMapWithSize(value.Type(), value.Len())
+        d.copies[key] = copied
+        iter := value.MapRange()
+        for iter.Next() {
+            copied.SetMapIndex(d.copy(iter.Key()), d.copy(iter.Value()))
+        }
+        return copied
+    case reflect.Slice:
+        if value.IsNil() {
+            return reflect.Zero(value.Type())
+        }
+        copied := reflect.MakeSlice(value.Type(), value.Len(), value.Cap())
+        for i := 0; i < value.Len(); i++ {
+            copied.Index(i).Set(d.copy(value.Index(i)))
+        }
+        return copied
+    case reflect.Array:
+        copied := reflect.New(value.Type()).Elem()
+        for i := 0; i < value.Len(); i++ {
+            copied.Index(i).Set(d.copy(value.Index(i)))
+        }
+        return copied
+    case reflect.Struct:
+        if lock := lockIn(value.Type()); lock != nil && d.lock == nil {
+            d.lock = lock
+        }
+        copied := reflect.New(value.Type()).Elem()
+        copied.Set(value) // Copies unexported fields as they are
+        for i := 0; i < copied.NumField(); i++ {
+            if !value.Type().Field(i).IsExported() {
+                continue
+            }
+            copied.Field(i).Set(d.copy(value.Field(i)))
+        }
+        return copied
+    case reflect.Interface:
+        if value.IsNil() {
+            return reflect.Zero(value.Type())
+        }
+        copied := reflect.New(value.Type()).Elem()
+        copied.Set(d.copy(value.Elem()))
+        return copied
+    default:
+        return value
+    }
+}
+
+// lockIn returns the type of a lock held by value in t, e.g. a sync.Mutex field, or nil.
+// Like go vet's copylocks check, a lock is a struct whose pointer has Lock and Unlock methods;
+// the types of sync and sync/atomic, such as sync.Once or atomic.Int64, count as locks too.
+func lockIn(t reflect.Type) reflect.Type {
+    switch t.Kind() {
+    case reflect.Struct:
+        if t.PkgPath() == "sync" || t.PkgPath() == "sync/atomic" {
+            return t
+        }
+        pointer := reflect.PointerTo(t)
+        if _, ok := pointer.MethodByName("Lock"); ok {
+            if _, ok := pointer.MethodByName("Unlock"); ok {
+                return t
+            }
+        }
+        for i := 0; i < t.NumField(); i++ {
+            if lock := lockIn(t.Field(i).Type); lock != nil {
+                return lock
+            }
+        }
+    case reflect.Array:
+        return lockIn(t.Elem())
+    }
+    return nil
+}
+
+// cloneMethod returns the Clone method of value when it takes no arguments and returns a
+// value of value's own type, which then makes copies instead of deepCopy
+func cloneMethod(value reflect.Value) (reflect.Value, bool) {
+    method := value.MethodByName("Clone")
+    if !method.IsValid() {
+        return reflect.Value{}, false
+    }
+    methodType := method.Type()
+    if methodType.NumIn() != 0 || methodType.NumOut() != 1 || !methodType.Out(0).AssignableTo(value.Type()) {
+        return reflect.Value{}, false
+    }
+    return method, true
+}
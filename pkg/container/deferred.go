@@ -0,0 +1,281 @@
+// pkg/container/deferred.go
+package container
+
+import (
+    "fmt"
+    "reflect"
+    "sort"
+    "strings"
+)
+
+// deferredService is a constructor recorded by RegisterDeferred and instantiated by Start
+type deferredService struct {
+    qualifier   string
+    constructor reflect.Value
+    scope       Scope
+}
+
+// RegisterDeferred records a constructor function without resolving its dependencies.
+// The constructor must return a single value or (value, error); each parameter is satisfied by
+// type from either another deferred constructor or an already-registered service.
+// Nothing is built until Start is called, so deferred registrations may appear in any order.
+func (c *Container) RegisterDeferred(qualifier string, constructor interface{}, scope Scope) error {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+
+    c.log.Infow("Registering deferred service",
+        "qualifier", qualifier,
+        "constructor", reflect.TypeOf(constructor),
+        "scope", scope)
+
+    if err := c.checkMutable("register", qualifier); err != nil {
+        return err
+    }
+    if err := c.validateQualifier(qualifier); err != nil {
+        return err
+    }
+
+    constructorValue := reflect.ValueOf(constructor)
+    if err := validateConstructor(constructorValue); err != nil {
+        c.log.Errorw("Invalid constructor", "qualifier", qualifier, "error", err)
+        return fmt.Errorf("invalid constructor for qualifier %s: %w", qualifier, err)
+    }
+
+    if _, exists := c.services[qualifier]; exists {
+        c.log.Errorw("Service already registered", "qualifier", qualifier)
+        return fmt.Errorf("service already registered for qualifier: %s", qualifier)
+    }
+    for _, pending := range c.deferred {
+        if pending.qualifier == qualifier {
+            c.log.Errorw("Service already registered", "qualifier", qualifier)
+            return fmt.Errorf("service already registered for qualifier: %s", qualifier)
+        }
+    }
+
+    c.deferred = append(c.deferred, &deferredService{
+        qualifier:   qualifier,
+        constructor: constructorValue,
+        scope:       scope,
+    })
+    return nil
+}
+
+// Start instantiates every deferred registration in dependency order.
+// Dependencies are matched by type, sorted topologically, and a cycle is reported as an error.
+// When a registration fails to build, it and the ones after it are kept pending, so a later
+// Start can retry them; the ones built before it stay registered.
+func (c *Container) Start() error {
+    c.mu.Lock()
+    pending := c.deferred
+    c.deferred = nil
+    registered := make(map[string]reflect.Type, len(c.services))
+    for qualifier, service := range c.services {
+        // Type is known before the first build, so lazy singletons and prototypes provide too
+        if service.Type != nil {
+            registered[qualifier] = service.Type
+        } else if service.Instance != nil {
+            registered[qualifier] = reflect.TypeOf(service.Instance)
+        }
+    }
+    c.mu.Unlock()
+
+    c.log.Infow("Starting deferred registrations", "count", len(pending))
+
+    byQualifier := make(map[string]*deferredService, len(pending))
+    for _, service := range pending {
+        byQualifier[service.qualifier] = service
+    }
+
+    // Work out which qualifier satisfies each constructor parameter
+    deps := make(map[string][]string, len(pending))
+    for _, service := range pending {
+        constructorType := service.constructor.Type()
+        for i := 0; i < constructorType.NumIn(); i++ {
+            provider, err := findProvider(constructorType.In(i), pending, registered)
+            if err != nil {
+                c.log.Errorw("Unsatisfied constructor dependency",
+                    "qualifier", service.qualifier,
+                    "parameter", i,
+                    "error", err)
+                c.restoreDeferred(pending)
+                return fmt.Errorf("cannot start %s: parameter %d: %w", service.qualifier, i, err)
+            }
+            deps[service.qualifier] = append(deps[service.qualifier], provider)
+        }
+    }
+
+    order, err := sortDeferred(pending, deps, byQualifier)
+    if err != nil {
+        c.log.Errorw("Failed to order deferred registrations", "error", err)
+        c.restoreDeferred(pending)
+        return err
+    }
+
+    for i, service := range order {
+        if err := c.instantiateDeferred(service, deps[service.qualifier]); err != nil {
+            c.restoreDeferred(order[i:])
+            return err
+        }
+    }
+
+    c.log.Infow("Started deferred registrations", "count", len(order))
+    return nil
+}
+
+// restoreDeferred puts back registrations taken by a Start that failed before building them
+func (c *Container) restoreDeferred(pending []*deferredService) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    c.deferred = append(pending, c.deferred...)
+}
+
+// instantiateDeferred builds a deferred service and registers it under its qualifier
+func (c *Container) instantiateDeferred(service *deferredService, deps []string) error {
+    build := func() (interface{}, error) {
+        args := make([]reflect.Value, len(deps))
+        for i, dep := range deps {
+            resolved, err := c.Resolve(dep)
+            if err != nil {
+                return nil, fmt.Errorf("failed to resolve dependency %s: %w", dep, err)
+            }
+            args[i] = reflect.ValueOf(resolved)
+        }
+        return callConstructor(service.constructor, args)
+    }
+
+    if service.scope == Prototype {
+        c.mu.Lock()
+        defer c.mu.Unlock()
+
+        if err := c.checkMutable("register", service.qualifier); err != nil {
+            return err
+        }
+        if _, exists := c.services[service.qualifier]; exists {
+            c.log.Errorw("Service already registered", "qualifier", service.qualifier)
+            return fmt.Errorf("service already registered for qualifier: %s", service.qualifier)
+        }
+        // Resolve reports constructor errors through construct; Factory only logs them
+        c.store(service.qualifier, &ScopedService{
+            Scope: Prototype,
+            Type:  service.constructor.Type().Out(0),
+            Factory: func() interface{} {
+                instance, err := build()
+                if err != nil {
+                    c.log.Errorw("Deferred constructor failed",
+                        "qualifier", service.qualifier,
+                        "error", err)
+                    return nil
+                }
+                return instance
+            },
+            Dependencies: deps,
+            construct:    build,
+        })
+        return nil
+    }
+
+    instance, err := build()
+    if err != nil {
+        c.log.Errorw("Deferred constructor failed", "qualifier", service.qualifier, "error", err)
+        return fmt.Errorf("failed to construct %s: %w", service.qualifier, err)
+    }
+    if err := c.Register(service.qualifier, instance, service.scope); err != nil {
+        return err
+    }
+
+    c.mu.Lock()
+    c.services[service.qualifier].Dependencies = deps
+    c.mu.Unlock()
+    return nil
+}
+
+// validateConstructor checks that v is a function returning T or (T, error)
+func validateConstructor(v reflect.Value) error {
+    if v.Kind() != reflect.Func {
+        return fmt.Errorf("constructor must be a function, got: %v", v.Kind())
+    }
+    t := v.Type()
+    switch {
+    case t.NumOut() == 1:
+    case t.NumOut() == 2 && t.Out(1) == errorType:
+    default:
+        return fmt.Errorf("constructor must return a value or (value, error), got: %v", t)
+    }
+    return nil
+}
+
+// callConstructor calls a validated constructor and unpacks its optional error result
+func callConstructor(constructor reflect.Value, args []reflect.Value) (interface{}, error) {
+    results := constructor.Call(args)
+    if len(results) == 2 && !results[1].IsNil() {
+        return nil, results[1].Interface().(error)
+    }
+    return results[0].Interface(), nil
+}
+
+// findProvider returns the single qualifier, deferred or registered, whose type satisfies t
+func findProvider(t reflect.Type, pending []*deferredService, registered map[string]reflect.Type) (string, error) {
+    candidates := make([]string, 0, 1)
+    for _, service := range pending {
+        if service.constructor.Type().Out(0).AssignableTo(t) {
+            candidates = append(candidates, service.qualifier)
+        }
+    }
+    for qualifier, serviceType := range registered {
+        if serviceType.AssignableTo(t) {
+            candidates = append(candidates, qualifier)
+        }
+    }
+
+    sort.Strings(candidates)
+
+    switch len(candidates) {
+    case 0:
+        return "", fmt.Errorf("no service provides type %v", t)
+    case 1:
+        return candidates[0], nil
+    default:
+        return "", fmt.Errorf("multiple services provide type %v: %s", t, strings.Join(candidates, ", "))
+    }
+}
+
+// sortDeferred orders deferred services so that every service comes after its deferred dependencies
+func sortDeferred(pending []*deferredService, deps map[string][]string, byQualifier map[string]*deferredService) ([]*deferredService, error) {
+    const (
+        unvisited = iota
+        visiting
+        visited
+    )
+    state := make(map[string]int, len(pending))
+    order := make([]*deferredService, 0, len(pending))
+
+    var visit func(qualifier string, path []string) error
+    visit = func(qualifier string, path []string) error {
+        switch state[qualifier] {
+        case visited:
+            return nil
+        case visiting:
+            return fmt.Errorf("dependency cycle detected: %s -> %s", strings.Join(path, " -> "), qualifier)
+        }
+        state[qualifier] = visiting
+        for _, dep := range deps[qualifier] {
+            if _, ok := byQualifier[dep]; !ok {
+                continue // already registered, nothing to order
+            }
+            // Copied, so sibling branches never share the backing array of path
+            if err := visit(dep, append(append(make([]string, 0, len(path)+1), path...), qualifier)); err != nil {
+                return err
+            }
+        }
+        state[qualifier] = visited
+        order = append(order, byQualifier[qualifier])
+        return nil
+    }
+
+    for _, service := range pending {
+        if err := visit(service.qualifier, nil); err != nil {
+            return nil, err
+        }
+    }
+    return order, nil
+}
@@ -0,0 +1,172 @@
+package container
+
+import (
+    "errors"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+type deferredRepo interface {
+    Find() string
+}
+
+type deferredRepoImpl struct{}
+
+func (r *deferredRepoImpl) Find() string { return "found" }
+
+type deferredHandler struct {
+    repo deferredRepo
+}
+
+type deferredCycleA struct{}
+type deferredCycleB struct{}
+
+func TestContainer_RegisterDeferred(t *testing.T) {
+    container := NewContainer()
+
+    // A (handler) is registered before B (repo) even though it depends on it
+    err := container.RegisterDeferred("handler", func(repo deferredRepo) *deferredHandler {
+        return &deferredHandler{repo: repo}
+    }, Singleton)
+    require.NoError(t, err)
+    err = container.RegisterDeferred("repo", func() (deferredRepo, error) {
+        return &deferredRepoImpl{}, nil
+    }, Singleton)
+    require.NoError(t, err)
+
+    // Nothing is built before Start
+    _, err = container.Resolve("handler")
+    assert.Error(t, err)
+
+    require.NoError(t, container.Start())
+
+    repo, err := container.Resolve("repo")
+    require.NoError(t, err)
+    handler, err := container.Resolve("handler")
+    require.NoError(t, err)
+    assert.Same(t, repo, handler.(*deferredHandler).repo)
+    assert.Equal(t, []string{"repo"}, container.services["handler"].Dependencies)
+}
+
+func TestContainer_RegisterDeferredCycle(t *testing.T) {
+    container := NewContainer()
+
+    require.NoError(t, container.RegisterDeferred("a", func(*deferredCycleB) *deferredCycleA {
+        return &deferredCycleA{}
+    }, Singleton))
+    require.NoError(t, container.RegisterDeferred("b", func(*deferredCycleA) *deferredCycleB {
+        return &deferredCycleB{}
+    }, Singleton))
+
+    err := container.Start()
+    assert.ErrorContains(t, err, "dependency cycle detected")
+}
+
+type deferredClock struct{}
+
+func TestContainer_RegisterDeferredPartialFailure(t *testing.T) {
+    container := NewContainer()
+    repoErr := errors.New("database unavailable")
+
+    require.NoError(t, container.RegisterDeferred("clock", func() *deferredClock {
+        return &deferredClock{}
+    }, Prototype))
+    require.NoError(t, container.RegisterDeferred("repo", func(*deferredClock) (deferredRepo, error) {
+        if repoErr != nil {
+            return nil, repoErr
+        }
+        return &deferredRepoImpl{}, nil
+    }, Singleton))
+    require.NoError(t, container.RegisterDeferred("handler", func(repo deferredRepo) *deferredHandler {
+        return &deferredHandler{repo: repo}
+    }, Singleton))
+
+    assert.ErrorIs(t, container.Start(), repoErr)
+    _, err := container.Resolve("clock")
+    assert.NoError(t, err, "services built before the failure stay registered")
+    _, err = container.Resolve("handler")
+    assert.Error(t, err)
+
+    // The failed registration and the ones after it are retried by the next Start
+    repoErr = nil
+    require.NoError(t, container.Start())
+    handler, err := container.Resolve("handler")
+    require.NoError(t, err)
+    assert.NotNil(t, handler.(*deferredHandler).repo)
+}
+
+func TestContainer_RegisterDeferredPrototypeErrors(t *testing.T) {
+    container := NewContainer()
+    calls := 0
+    require.NoError(t, container.RegisterDeferred("repo", func() (deferredRepo, error) {
+        calls++
+        return nil, errors.New("connection refused")
+    }, Prototype))
+    require.NoError(t, container.Start())
+
+    _, err := container.Resolve("repo")
+    assert.EqualError(t, err, "failed to construct repo: connection refused")
+    assert.Equal(t, 1, calls)
+
+    // A qualifier registered while the prototype was pending is not overwritten
+    require.NoError(t, container.RegisterDeferred("clock", func() *deferredClock {
+        return &deferredClock{}
+    }, Prototype))
+    existing := &TestStruct{}
+    require.NoError(t, container.Register("clock", existing, Singleton))
+    assert.ErrorContains(t, container.Start(), "service already registered for qualifier: clock")
+    resolved, err := container.Resolve("clock")
+    require.NoError(t, err)
+    assert.Same(t, existing, resolved)
+}
+
+func TestContainer_RegisterDeferredLazyProvider(t *testing.T) {
+    container := NewContainer()
+    // Not built until first resolve, yet its type is known up front
+    require.NoError(t, container.RegisterLazy("repo", func() *deferredRepoImpl {
+        return &deferredRepoImpl{}
+    }))
+    require.NoError(t, container.RegisterDeferred("handler", func(repo deferredRepo) *deferredHandler {
+        return &deferredHandler{repo: repo}
+    }, Singleton))
+
+    require.NoError(t, container.Start())
+    repo, err := container.Resolve("repo")
+    require.NoError(t, err)
+    handler, err := container.Resolve("handler")
+    require.NoError(t, err)
+    assert.Same(t, repo, handler.(*deferredHandler).repo)
+}
+
+func TestContainer_RegisterDeferredAmbiguousProvider(t *testing.T) {
+    container := NewContainer()
+    require.NoError(t, container.Register("repoC", &deferredRepoImpl{}, Singleton))
+    require.NoError(t, container.RegisterLazy("repoA", func() *deferredRepoImpl {
+        return &deferredRepoImpl{}
+    }))
+    require.NoError(t, container.RegisterDeferred("repoB", func() deferredRepo {
+        return &deferredRepoImpl{}
+    }, Singleton))
+    require.NoError(t, container.RegisterDeferred("handler", func(repo deferredRepo) *deferredHandler {
+        return &deferredHandler{repo: repo}
+    }, Singleton))
+
+    // Candidates are listed in a stable order
+    assert.EqualError(t, container.Start(),
+        "cannot start handler: parameter 0: multiple services provide type container.deferredRepo: repoA, repoB, repoC")
+}
+
+func TestContainer_RegisterDeferredInvalid(t *testing.T) {
+    container := NewContainer()
+
+    assert.Error(t, container.RegisterDeferred("notFunc", "value", Singleton))
+    assert.Error(t, container.RegisterDeferred("badReturn", func() (int, string) { return 0, "" }, Singleton))
+
+    require.NoError(t, container.RegisterDeferred("handler", func(repo deferredRepo) *deferredHandler {
+        return &deferredHandler{repo: repo}
+    }, Singleton))
+    assert.Error(t, container.RegisterDeferred("handler", func() *deferredHandler { return nil }, Singleton))
+    assert.ErrorContains(t, container.Start(), "no service provides type")
+}
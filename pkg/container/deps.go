@@ -0,0 +1,258 @@
+// pkg/container/deps.go
+package container
+
+import (
+    "errors"
+    "fmt"
+    "reflect"
+    "sort"
+    "strings"
+)
+
+// RegisterWithDeps registers a service and records the qualifiers it depends on.
+// Declared dependencies are merged with the ones found in the service's di tags and are
+// used by Cleanup for teardown ordering and by Validate for reachability checks.
+func (c *Container) RegisterWithDeps(qualifier string, service interface{}, scope Scope, deps ...string) error {
+    return c.register(qualifier, service, scope, func(s *ScopedService) {
+        s.Dependencies = mergeDependencies(s.Dependencies, deps)
+    })
+}
+
+// Validate checks that every recorded dependency can be resolved and that the
+// dependency graph has no cycles. All problems found are returned joined together.
+// It also logs a warning for every singleton that injects a prototype through a di tag, since
+// the singleton pins a single instance of it; provider fields are exempt as they resolve anew.
+func (c *Container) Validate() error {
+    c.mu.RLock()
+    services := make(map[string]*ScopedService, len(c.services))
+    for qualifier, service := range c.services {
+        services[qualifier] = service
+    }
+    requiredByDefault := c.requiredByDefault
+    c.mu.RUnlock()
+
+    qualifiers := sortedQualifiers(services)
+    var errs []error
+    for _, qualifier := range qualifiers {
+        service := services[qualifier]
+        optional := optionalTagDependencies(service.Type, requiredByDefault)
+        for _, dep := range service.Dependencies {
+            if optional[dep] || c.Has(dep) {
+                continue
+            }
+            c.log.Warnw("Unresolvable dependency",
+                "qualifier", qualifier,
+                "dependency", dep)
+            errs = append(errs, fmt.Errorf("service %s depends on missing service %s", qualifier, dep))
+        }
+    }
+
+    if _, err := dependencyOrder(services); err != nil {
+        errs = append(errs, err)
+    }
+    c.warnScopePromotions(services)
+
+    if len(errs) > 0 {
+        return errors.Join(errs...)
+    }
+    c.log.Infow("Container validated", "services", len(services))
+    return nil
+}
+
+// warnScopePromotions logs a warning for each field of a cached service injecting a prototype
+func (c *Container) warnScopePromotions(services map[string]*ScopedService) {
+    for _, qualifier := range sortedQualifiers(services) {
+        service := services[qualifier]
+        if !service.Scope.caches() || service.Type == nil {
+            continue
+        }
+        t := service.Type
+        if t.Kind() == reflect.Ptr {
+            t = t.Elem()
+        }
+        if t.Kind() != reflect.Struct {
+            continue
+        }
+        for _, field := range injectionPlanFor(t) {
+            if !field.tagged || !field.exported || field.all || field.prefix != "" ||
+                hasPlaceholders(field.qualifier) || isProviderType(t.Field(field.index).Type) {
+                continue
+            }
+            if scope, ok := c.registeredScope(field.qualifier); !ok || scope != Prototype {
+                continue
+            }
+            c.log.Warnw("Singleton injects a prototype, which is pinned to a single instance",
+                "qualifier", qualifier,
+                "scope", service.Scope,
+                "field", field.name,
+                "dependency", field.qualifier)
+        }
+    }
+}
+
+// registeredScope returns the scope of the service registered for qualifier in this container
+// or its ancestors
+func (c *Container) registeredScope(qualifier string) (Scope, bool) {
+    c.mu.RLock()
+    service, exists := c.services[qualifier]
+    parent := c.parent
+    c.mu.RUnlock()
+
+    if exists {
+        return service.Scope, true
+    }
+    if parent != nil {
+        return parent.registeredScope(qualifier)
+    }
+    return 0, false
+}
+
+// Has reports whether qualifier is registered, possibly as profile variants, in this container
+// or one of its ancestors
+func (c *Container) Has(qualifier string) bool {
+    c.mu.RLock()
+    _, exists := c.services[qualifier]
+    _, variants := c.profileVariants[qualifier]
+    parent := c.parent
+    c.mu.RUnlock()
+
+    if exists || variants {
+        return true
+    }
+    return parent != nil && parent.Has(qualifier)
+}
+
+// DependencyGraph returns the dependency graph of this container as an adjacency list:
+// every qualifier maps to the sorted qualifiers it depends on, from di tags and recorded
+// dependencies. Services without dependencies map to an empty slice.
+func (c *Container) DependencyGraph() map[string][]string {
+    c.mu.RLock()
+    defer c.mu.RUnlock()
+
+    graph := make(map[string][]string, len(c.services))
+    for qualifier, service := range c.services {
+        deps := mergeDependencies(nil, service.Dependencies)
+        sort.Strings(deps)
+        graph[qualifier] = deps
+    }
+    return graph
+}
+
+// tagDependencies returns the di qualifiers declared by a struct (or pointer to struct) type.
+// Qualifiers with placeholders depend on runtime state and are left out.
+func tagDependencies(t reflect.Type) []string {
+    if t == nil {
+        return nil
+    }
+    if t.Kind() == reflect.Ptr {
+        t = t.Elem()
+    }
+    if t.Kind() != reflect.Struct {
+        return nil
+    }
+
+    deps := make([]string, 0)
+    for _, field := range injectionPlanFor(t) {
+        if field.tagged && field.exported && field.qualifier != "" && !field.all && field.prefix == "" &&
+            !hasPlaceholders(field.qualifier) {
+            deps = append(deps, field.qualifier)
+        }
+    }
+    return deps
+}
+
+// optionalTagDependencies returns the di qualifiers of a type whose fields are not required
+func optionalTagDependencies(t reflect.Type, requiredByDefault bool) map[string]bool {
+    optional := make(map[string]bool)
+    if t == nil {
+        return optional
+    }
+    if t.Kind() == reflect.Ptr {
+        t = t.Elem()
+    }
+    if t.Kind() != reflect.Struct {
+        return optional
+    }
+    for _, field := range injectionPlanFor(t) {
+        if field.tagged && !field.isRequired(requiredByDefault) {
+            optional[field.qualifier] = true
+        }
+    }
+    return optional
+}
+
+// mergeDependencies appends deps to existing, skipping duplicates and empty qualifiers
+func mergeDependencies(existing []string, deps []string) []string {
+    seen := make(map[string]bool, len(existing)+len(deps))
+    merged := make([]string, 0, len(existing)+len(deps))
+    for _, dep := range append(append([]string{}, existing...), deps...) {
+        if dep == "" || seen[dep] {
+            continue
+        }
+        seen[dep] = true
+        merged = append(merged, dep)
+    }
+    return merged
+}
+
+// dependencyOrder sorts qualifiers so that every service comes after the services it depends on.
+// Services unrelated by dependencies keep their registration order.
+// Dependencies outside the given set are ignored; a cycle is reported as an error.
+func dependencyOrder(services map[string]*ScopedService) ([]string, error) {
+    const (
+        unvisited = iota
+        visiting
+        visited
+    )
+    state := make(map[string]int, len(services))
+    order := make([]string, 0, len(services))
+
+    var visit func(qualifier string, path []string) error
+    visit = func(qualifier string, path []string) error {
+        switch state[qualifier] {
+        case visited:
+            return nil
+        case visiting:
+            return fmt.Errorf("dependency cycle detected: %s -> %s", strings.Join(path, " -> "), qualifier)
+        }
+        state[qualifier] = visiting
+        for _, dep := range services[qualifier].Dependencies {
+            if _, ok := services[dep]; !ok {
+                continue
+            }
+            // Copied, so sibling branches never share the backing array of path
+            if err := visit(dep, append(append(make([]string, 0, len(path)+1), path...), qualifier)); err != nil {
+                return err
+            }
+        }
+        state[qualifier] = visited
+        order = append(order, qualifier)
+        return nil
+    }
+
+    for _, qualifier := range registrationOrder(services) {
+        if err := visit(qualifier, nil); err != nil {
+            return nil, err
+        }
+    }
+    return order, nil
+}
+
+// registrationOrder returns the keys of services in the order they were registered
+func registrationOrder(services map[string]*ScopedService) []string {
+    qualifiers := sortedQualifiers(services)
+    sort.SliceStable(qualifiers, func(i, j int) bool {
+        return services[qualifiers[i]].order < services[qualifiers[j]].order
+    })
+    return qualifiers
+}
+
+// sortedQualifiers returns the keys of services in lexical order
+func sortedQualifiers(services map[string]*ScopedService) []string {
+    qualifiers := make([]string, 0, len(services))
+    for qualifier := range services {
+        qualifiers = append(qualifiers, qualifier)
+    }
+    sort.Strings(qualifiers)
+    return qualifiers
+}
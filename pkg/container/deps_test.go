@@ -0,0 +1,162 @@
+package container
+
+import (
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+    "go.uber.org/zap"
+    "go.uber.org/zap/zapcore"
+    "go.uber.org/zap/zaptest/observer"
+)
+
+type destroyRecorder struct {
+    name      string
+    destroyed *[]string
+}
+
+func (d *destroyRecorder) PostConstruct() error { return nil }
+
+func (d *destroyRecorder) PreDestroy() error {
+    *d.destroyed = append(*d.destroyed, d.name)
+    return nil
+}
+
+func TestContainer_RegisterWithDeps(t *testing.T) {
+    container := NewContainer()
+
+    require.NoError(t, container.RegisterWithDeps("consumer", &TestStruct{}, Singleton, "extraService", "testService"))
+
+    // Tag dependencies come first, declared ones are appended without duplicates
+    assert.Equal(t,
+        []string{"testService", "optionalService", "extraService"},
+        container.services["consumer"].Dependencies)
+
+    // Plain registration records the tag dependencies too
+    require.NoError(t, container.Register("plainConsumer", &TestStruct{}, Singleton))
+    assert.Equal(t,
+        []string{"testService", "optionalService"},
+        container.services["plainConsumer"].Dependencies)
+}
+
+type requiringStruct struct {
+    Service  TestService `di:"testService" required:"true"`
+    Optional TestService `di:"optionalService"`
+}
+
+func TestContainer_Validate(t *testing.T) {
+    container := NewContainer()
+    require.NoError(t, container.RegisterWithDeps("consumer", &requiringStruct{}, Singleton, "extraService"))
+
+    // testService is required by tag and extraService was declared; optionalService is excused
+    err := container.Validate()
+    require.Error(t, err)
+    assert.Contains(t, err.Error(), "consumer depends on missing service testService")
+    assert.Contains(t, err.Error(), "consumer depends on missing service extraService")
+    assert.NotContains(t, err.Error(), "optionalService")
+
+    require.NoError(t, container.Register("testService", &testServiceImpl{name: "test"}, Singleton))
+    require.NoError(t, container.Register("extraService", &testServiceImpl{name: "extra"}, Singleton))
+    assert.NoError(t, container.Validate())
+}
+
+func TestContainer_ValidateCycle(t *testing.T) {
+    container := NewContainer()
+    require.NoError(t, container.RegisterWithDeps("a", &testServiceImpl{name: "a"}, Singleton, "b"))
+    require.NoError(t, container.RegisterWithDeps("b", &testServiceImpl{name: "b"}, Singleton, "a"))
+
+    assert.ErrorContains(t, container.Validate(), "dependency cycle detected")
+}
+
+func TestContainer_CleanupDependencyOrder(t *testing.T) {
+    container := NewContainer()
+    var destroyed []string
+
+    // "alpha" sorts first but depends on "beta", so it must be destroyed first
+    require.NoError(t, container.Register("beta", &destroyRecorder{name: "beta", destroyed: &destroyed}, Singleton))
+    require.NoError(t, container.RegisterWithDeps("alpha", &destroyRecorder{name: "alpha", destroyed: &destroyed}, Singleton, "beta"))
+
+    require.NoError(t, container.Cleanup())
+    assert.Equal(t, []string{"alpha", "beta"}, destroyed)
+}
+
+type orderService struct {
+    Payments      TestService `di:"paymentService" required:"true"`
+    Inventory     TestService `di:"inventoryService" required:"true"`
+    Notifications TestService `di:"notificationService"`
+}
+
+func TestContainer_DependencyGraph(t *testing.T) {
+    container := NewContainer()
+    require.NoError(t, container.Register("orderService", &orderService{}, Singleton))
+    require.NoError(t, container.RegisterWithDeps("auditService", &testServiceImpl{}, Singleton, "orderService", "clock"))
+    require.NoError(t, container.Register("paymentService", &testServiceImpl{}, Singleton))
+
+    assert.Equal(t, map[string][]string{
+        "orderService":   {"inventoryService", "notificationService", "paymentService"},
+        "auditService":   {"clock", "orderService"},
+        "paymentService": {},
+    }, container.DependencyGraph())
+
+    // The result is a copy
+    container.DependencyGraph()["orderService"][0] = "changed"
+    assert.Equal(t, "inventoryService", container.DependencyGraph()["orderService"][0])
+}
+
+func TestContainer_CleanupTopologicalOrder(t *testing.T) {
+    container := NewContainer()
+    var destroyed []string
+    recorder := func(name string) *destroyRecorder {
+        return &destroyRecorder{name: name, destroyed: &destroyed}
+    }
+
+    // Registered so that neither registration nor lexical order matches the dependencies
+    require.NoError(t, container.RegisterWithDeps("c", recorder("c"), Singleton, "a"))
+    require.NoError(t, container.RegisterWithDeps("a", recorder("a"), Singleton, "b"))
+    require.NoError(t, container.Register("b", recorder("b"), Singleton))
+    require.NoError(t, container.Register("standalone1", recorder("standalone1"), Singleton))
+    require.NoError(t, container.Register("standalone2", recorder("standalone2"), Singleton))
+
+    require.NoError(t, container.Cleanup())
+    // Unrelated services go in reverse registration order
+    assert.Equal(t, []string{"standalone2", "standalone1", "c", "a", "b"}, destroyed)
+}
+
+func TestContainer_CleanupCycleFallsBackToReverseRegistration(t *testing.T) {
+    container := NewContainer()
+    var destroyed []string
+
+    require.NoError(t, container.RegisterWithDeps("y", &destroyRecorder{name: "y", destroyed: &destroyed}, Singleton, "x"))
+    require.NoError(t, container.RegisterWithDeps("x", &destroyRecorder{name: "x", destroyed: &destroyed}, Singleton, "y"))
+    require.NoError(t, container.Register("a", &destroyRecorder{name: "a", destroyed: &destroyed}, Singleton))
+
+    require.NoError(t, container.Cleanup())
+    assert.Equal(t, []string{"a", "x", "y"}, destroyed)
+}
+
+type reportGenerator struct {
+    Formatter TestService        `di:"formatter"`
+    Clock     TestService        `di:"clock"`
+    Fresh     func() TestService `di:"formatter"`
+}
+
+func TestContainer_ValidateWarnsAboutPrototypeInSingleton(t *testing.T) {
+    container := NewContainer()
+    core, logs := observer.New(zapcore.WarnLevel)
+    container.log = zap.New(core).Sugar()
+
+    require.NoError(t, container.RegisterPrototypeFactory("formatter", func() interface{} { return &testServiceImpl{} }))
+    require.NoError(t, container.Register("clock", &testServiceImpl{}, Singleton))
+    require.NoError(t, container.Register("reports", &reportGenerator{}, Singleton))
+    require.NoError(t, container.Register("reportsPerRequest", &reportGenerator{}, Prototype))
+    logs.TakeAll()
+
+    require.NoError(t, container.Validate(), "scope promotions are warnings, not errors")
+
+    warnings := logs.FilterMessage("Singleton injects a prototype, which is pinned to a single instance").All()
+    require.Len(t, warnings, 1, "only the singleton's plain field is reported")
+    fields := warnings[0].ContextMap()
+    assert.Equal(t, "reports", fields["qualifier"])
+    assert.Equal(t, "Formatter", fields["field"])
+    assert.Equal(t, "formatter", fields["dependency"])
+}
@@ -0,0 +1,89 @@
+// pkg/container/diff.go
+package container
+
+import (
+    "fmt"
+    "reflect"
+)
+
+// ContainerDiff describes how the registrations of two containers differ.
+// Only the containers' own services are compared, not those of their parents.
+type ContainerDiff struct {
+    OnlyInA []string        // Qualifiers registered only in the first container, sorted
+    OnlyInB []string        // Qualifiers registered only in the second container, sorted
+    Changed []ServiceChange // Qualifiers registered in both with a different scope or type, sorted
+}
+
+// ServiceChange describes a qualifier registered differently in two containers
+type ServiceChange struct {
+    Qualifier string
+    ScopeA    Scope
+    ScopeB    Scope
+    TypeA     reflect.Type // Concrete type in the first container, nil if unknown
+    TypeB     reflect.Type // Concrete type in the second container, nil if unknown
+}
+
+// Empty reports whether the containers have the same registrations
+func (d ContainerDiff) Empty() bool {
+    return len(d.OnlyInA) == 0 && len(d.OnlyInB) == 0 && len(d.Changed) == 0
+}
+
+// String describes the change, e.g. "cache: scope Singleton -> Prototype"
+func (s ServiceChange) String() string {
+    description := s.Qualifier + ":"
+    if s.ScopeA != s.ScopeB {
+        description += fmt.Sprintf(" scope %s -> %s", s.ScopeA, s.ScopeB)
+    }
+    if s.TypeA != s.TypeB {
+        description += fmt.Sprintf(" type %v -> %v", s.TypeA, s.TypeB)
+    }
+    return description
+}
+
+// Diff compares the registrations of a and b, e.g. to assert that a rewired container still
+// matches a baseline. Services are compared by qualifier, scope and concrete type.
+func Diff(a, b *Container) ContainerDiff {
+    servicesA := a.snapshotServices()
+    servicesB := b.snapshotServices()
+
+    diff := ContainerDiff{
+        OnlyInA: make([]string, 0),
+        OnlyInB: make([]string, 0),
+        Changed: make([]ServiceChange, 0),
+    }
+    for _, qualifier := range sortedQualifiers(servicesA) {
+        serviceA := servicesA[qualifier]
+        serviceB, ok := servicesB[qualifier]
+        if !ok {
+            diff.OnlyInA = append(diff.OnlyInA, qualifier)
+            continue
+        }
+        if serviceA.Scope != serviceB.Scope || serviceA.Type != serviceB.Type {
+            diff.Changed = append(diff.Changed, ServiceChange{
+                Qualifier: qualifier,
+                ScopeA:    serviceA.Scope,
+                ScopeB:    serviceB.Scope,
+                TypeA:     serviceA.Type,
+                TypeB:     serviceB.Type,
+            })
+        }
+    }
+    for _, qualifier := range sortedQualifiers(servicesB) {
+        if _, ok := servicesA[qualifier]; !ok {
+            diff.OnlyInB = append(diff.OnlyInB, qualifier)
+        }
+    }
+    return diff
+}
+
+// snapshotServices copies the scope and type of every service under the read lock
+func (c *Container) snapshotServices() map[string]*ScopedService {
+    c.mu.RLock()
+    defer c.mu.RUnlock()
+
+    services := make(map[string]*ScopedService, len(c.services))
+    for qualifier, service := range c.services {
+        services[qualifier] = &ScopedService{Scope: service.Scope, Type: service.Type}
+    }
+    return services
+}
@@ -0,0 +1,48 @@
+package container
+
+import (
+    "reflect"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+    baseline := NewContainer()
+    require.NoError(t, baseline.Register("shared", &testServiceImpl{}, Singleton))
+    require.NoError(t, baseline.Register("cache", &testServiceImpl{}, Singleton))
+    require.NoError(t, baseline.Register("legacy", &TestStruct{}, Singleton))
+
+    rewired := NewContainer()
+    require.NoError(t, rewired.Register("shared", &testServiceImpl{}, Singleton))
+    require.NoError(t, rewired.Register("cache", &testServiceImpl{}, WeakSingleton))
+    require.NoError(t, rewired.Register("metrics", &TestStruct{}, Singleton))
+
+    diff := Diff(baseline, rewired)
+    assert.Equal(t, []string{"legacy"}, diff.OnlyInA)
+    assert.Equal(t, []string{"metrics"}, diff.OnlyInB)
+    serviceType := reflect.TypeOf(&testServiceImpl{})
+    assert.Equal(t, []ServiceChange{{
+        Qualifier: "cache",
+        ScopeA:    Singleton,
+        ScopeB:    WeakSingleton,
+        TypeA:     serviceType,
+        TypeB:     serviceType,
+    }}, diff.Changed)
+    assert.Equal(t, "cache: scope Singleton -> WeakSingleton", diff.Changed[0].String())
+    assert.False(t, diff.Empty())
+
+    assert.True(t, Diff(baseline, baseline).Empty())
+}
+
+func TestDiff_TypeChange(t *testing.T) {
+    a := NewContainer()
+    b := NewContainer()
+    require.NoError(t, a.Register("service", &testServiceImpl{}, Singleton))
+    require.NoError(t, b.Register("service", &TestStruct{}, Singleton))
+
+    diff := Diff(a, b)
+    require.Len(t, diff.Changed, 1)
+    assert.Equal(t, "service: type *container.testServiceImpl -> *container.TestStruct", diff.Changed[0].String())
+}
@@ -0,0 +1,28 @@
+// pkg/container/dump.go
+package container
+
+import (
+    "fmt"
+    "sort"
+    "strings"
+)
+
+// Dump returns a human-readable listing of every registered service, sorted by qualifier.
+// It is meant for debugging and its format may change.
+func (c *Container) Dump() string {
+    c.mu.RLock()
+    defer c.mu.RUnlock()
+
+    qualifiers := make([]string, 0, len(c.services))
+    for qualifier := range c.services {
+        qualifiers = append(qualifiers, qualifier)
+    }
+    sort.Strings(qualifiers)
+
+    var builder strings.Builder
+    builder.WriteString(fmt.Sprintf("Container %d: %d services\n", c.id, len(qualifiers)))
+    for _, qualifier := range qualifiers {
+        builder.WriteString(fmt.Sprintf("  - %s: %s\n", qualifier, c.services[qualifier]))
+    }
+    return builder.String()
+}
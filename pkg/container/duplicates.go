@@ -0,0 +1,38 @@
+// pkg/container/duplicates.go
+package container
+
+import (
+    "reflect"
+    "sort"
+)
+
+// FindDuplicateInstances reports instances that are registered under more than one qualifier
+// in this container, which usually points to a copy-paste wiring mistake. The result maps each
+// shared instance pointer to the sorted qualifiers holding it; it is empty when nothing is
+// shared. Only cached pointer instances are compared, so prototypes never show up.
+func (c *Container) FindDuplicateInstances() map[uintptr][]string {
+    c.mu.RLock()
+    byPointer := make(map[uintptr][]string)
+    for qualifier, service := range c.services {
+        if service.Instance == nil {
+            continue
+        }
+        value := reflect.ValueOf(service.Instance)
+        if value.Kind() != reflect.Ptr || value.IsNil() {
+            continue
+        }
+        byPointer[value.Pointer()] = append(byPointer[value.Pointer()], qualifier)
+    }
+    c.mu.RUnlock()
+
+    duplicates := make(map[uintptr][]string)
+    for pointer, qualifiers := range byPointer {
+        if len(qualifiers) < 2 {
+            continue
+        }
+        sort.Strings(qualifiers)
+        duplicates[pointer] = qualifiers
+        c.log.Warnw("Instance registered under several qualifiers", "qualifiers", qualifiers)
+    }
+    return duplicates
+}
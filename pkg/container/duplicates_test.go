@@ -0,0 +1,27 @@
+package container
+
+import (
+    "reflect"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+func TestContainer_FindDuplicateInstances(t *testing.T) {
+    container := NewContainer()
+    assert.Empty(t, container.FindDuplicateInstances())
+
+    shared := &TestStruct{}
+    require.NoError(t, container.Register("userRepo", shared, Singleton))
+    require.NoError(t, container.Register("orderRepo", shared, Singleton))
+    require.NoError(t, container.Register("auditRepo", &TestStruct{}, Singleton))
+    require.NoError(t, container.Register("prototypeRepo", &TestStruct{}, Prototype))
+    require.NoError(t, container.Register("port", 8080, Singleton))
+    require.NoError(t, container.Register("fallbackPort", 8080, Singleton))
+
+    duplicates := container.FindDuplicateInstances()
+    assert.Equal(t, map[uintptr][]string{
+        reflect.ValueOf(shared).Pointer(): {"orderRepo", "userRepo"},
+    }, duplicates)
+}
@@ -0,0 +1,43 @@
+// pkg/container/evict.go
+package container
+
+import (
+    "fmt"
+)
+
+// Evict drops the cached instance of a WeakSingleton after running its pre-destroy lifecycle.
+// The next resolve rebuilds the instance from its factory and runs post-construct again.
+// Evicting a service that has not been built yet is a no-op.
+func (c *Container) Evict(qualifier string) error {
+    c.mu.Lock()
+    scopedService, exists := c.services[qualifier]
+    if !exists {
+        c.mu.Unlock()
+        c.log.Errorw("Cannot evict unknown service", "qualifier", qualifier)
+        return fmt.Errorf("no service found for qualifier: %s", qualifier)
+    }
+    if scopedService.Scope != WeakSingleton {
+        c.mu.Unlock()
+        c.log.Errorw("Cannot evict service that is not a weak singleton",
+            "qualifier", qualifier,
+            "scope", scopedService.Scope)
+        return fmt.Errorf("cannot evict %s: scope is %s, not %s", qualifier, scopedService.Scope, WeakSingleton)
+    }
+    instance := scopedService.destroyable()
+    external := scopedService.external
+    scopedService.Instance = nil
+    scopedService.undecorated = nil
+    scopedService.external = false // A rebuilt instance is owned by this container
+    c.mu.Unlock()
+
+    if instance == nil {
+        return nil
+    }
+    if external {
+        c.log.Infow("Evicting weak singleton owned elsewhere without destroying it", "qualifier", qualifier)
+        return nil
+    }
+
+    c.log.Infow("Evicting weak singleton", "qualifier", qualifier)
+    return c.preDestroy(qualifier, WeakSingleton, instance)
+}
@@ -0,0 +1,42 @@
+package container
+
+import (
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+func TestContainer_WeakSingletonEvict(t *testing.T) {
+    container := NewContainer()
+    require.NoError(t, container.Register("cache", &testServiceImpl{name: "cache"}, WeakSingleton))
+
+    first, err := container.Resolve("cache")
+    require.NoError(t, err)
+    again, err := container.Resolve("cache")
+    require.NoError(t, err)
+    assert.Same(t, first, again)
+    assert.True(t, first.(*testServiceImpl).initialized)
+
+    require.NoError(t, container.Evict("cache"))
+    assert.True(t, first.(*testServiceImpl).destroyed)
+
+    rebuilt, err := container.Resolve("cache")
+    require.NoError(t, err)
+    assert.NotSame(t, first, rebuilt)
+    assert.True(t, rebuilt.(*testServiceImpl).initialized)
+    assert.False(t, rebuilt.(*testServiceImpl).destroyed)
+    assert.Equal(t, "cache", rebuilt.(*testServiceImpl).name)
+}
+
+func TestContainer_EvictErrors(t *testing.T) {
+    container := NewContainer()
+    require.NoError(t, container.Register("singleton", &testServiceImpl{name: "s"}, Singleton))
+    require.NoError(t, container.Register("cache", &testServiceImpl{name: "c"}, WeakSingleton))
+
+    assert.Error(t, container.Evict("missing"))
+    assert.ErrorContains(t, container.Evict("singleton"), "not WeakSingleton")
+
+    // Evicting before the first resolve is a no-op
+    assert.NoError(t, container.Evict("cache"))
+}
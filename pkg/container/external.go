@@ -0,0 +1,21 @@
+// pkg/container/external.go
+package container
+
+import (
+    "fmt"
+)
+
+// RegisterExternal registers an already-constructed object the container does not own, such as
+// a third-party client. It is stored as-is: PostConstruct, PreDestroy, lifecycle hooks and
+// post-processors are never run for it, even if it implements LifecycleAware, and Cleanup
+// leaves it alone. Only the Singleton scope is supported, since any other scope would make
+// the container build new instances.
+func (c *Container) RegisterExternal(qualifier string, service interface{}, scope Scope) error {
+    if scope != Singleton {
+        c.log.Errorw("External services must be singletons", "qualifier", qualifier, "scope", scope)
+        return fmt.Errorf("cannot register external service %s as %s, only Singleton is supported", qualifier, scope)
+    }
+    return c.register(qualifier, service, scope, func(s *ScopedService) {
+        s.external = true
+    })
+}
@@ -0,0 +1,37 @@
+package container
+
+import (
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+func TestContainer_RegisterExternal(t *testing.T) {
+    container := NewContainer()
+    hookCalls := 0
+    container.GetLifecycleManager().AddPostConstructHook(LifecycleHook{
+        Name:    "count",
+        Handler: func(interface{}) error { hookCalls++; return nil },
+    })
+    container.AddPostProcessor(func(qualifier string, instance interface{}) (interface{}, error) {
+        return &testServiceImpl{name: "replaced"}, nil
+    })
+
+    client := &testServiceImpl{name: "thirdParty"}
+    require.NoError(t, container.RegisterExternal("client", client, Singleton))
+
+    resolved, err := container.Resolve("client")
+    require.NoError(t, err)
+    assert.Same(t, client, resolved)
+    _, err = container.ResolveWithProfiles("client")
+    require.NoError(t, err)
+
+    require.NoError(t, container.Cleanup())
+    assert.False(t, client.initialized, "PostConstruct was not called")
+    assert.False(t, client.destroyed, "PreDestroy was not called")
+    assert.Zero(t, hookCalls)
+
+    err = container.RegisterExternal("prototypeClient", &testServiceImpl{}, Prototype)
+    assert.ErrorContains(t, err, "only Singleton is supported")
+}
@@ -0,0 +1,44 @@
+// pkg/container/fallback.go
+package container
+
+// FallbackResolver supplies a service for a qualifier that is registered neither in the
+// container nor in its ancestors. It returns ok=false when it cannot provide one either.
+type FallbackResolver func(qualifier string) (service interface{}, scope Scope, ok bool)
+
+// SetFallbackResolver installs a resolver consulted when Resolve finds no registration,
+// e.g. to load plugins lazily. A service it supplies is registered under the qualifier with
+// the returned scope, so it is only asked once per qualifier. Passing nil removes it.
+// It panics on a frozen container.
+func (c *Container) SetFallbackResolver(resolver FallbackResolver) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    if err := c.checkMutable("set", "fallback resolver"); err != nil {
+        panic(err)
+    }
+    c.fallbackResolver = resolver
+}
+
+// resolveFallback asks the fallback resolver for qualifier, registers what it supplies and
+// resolves it. ok is false when there is no fallback resolver or it has nothing to offer.
+func (c *Container) resolveFallback(qualifier string) (instance interface{}, scope Scope, ok bool, err error) {
+    c.mu.RLock()
+    resolver := c.fallbackResolver
+    c.mu.RUnlock()
+    if resolver == nil {
+        return nil, 0, false, nil
+    }
+
+    service, scope, ok := resolver(qualifier)
+    if !ok {
+        c.log.Debugw("Fallback resolver has no service", "qualifier", qualifier)
+        return nil, 0, false, nil
+    }
+
+    c.log.Infow("Registering service from fallback resolver", "qualifier", qualifier, "scope", scope)
+    // A concurrent resolve may have registered it first, in which case that binding is used
+    if err := c.Register(qualifier, service, scope); err != nil && !c.Has(qualifier) {
+        return nil, scope, true, err
+    }
+    instance, scope, err = c.resolve(qualifier, 0, nil)
+    return instance, scope, true, err
+}
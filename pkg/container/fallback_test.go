@@ -0,0 +1,41 @@
+package container
+
+import (
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+func TestContainer_SetFallbackResolver(t *testing.T) {
+    container := NewContainer()
+    plugin := &testServiceImpl{name: "plugin"}
+    asked := make([]string, 0)
+    container.SetFallbackResolver(func(qualifier string) (interface{}, Scope, bool) {
+        asked = append(asked, qualifier)
+        if qualifier == "plugin.greeter" {
+            return plugin, Singleton, true
+        }
+        return nil, Singleton, false
+    })
+    registered := &TestStruct{}
+    require.NoError(t, container.Register("registered", registered, Singleton))
+
+    resolved, err := container.Resolve("plugin.greeter")
+    require.NoError(t, err)
+    assert.Same(t, plugin, resolved)
+    assert.True(t, plugin.initialized, "fallback services go through the normal lifecycle")
+
+    // The supplied service is now registered, so the fallback is not asked again
+    resolved, err = container.Resolve("plugin.greeter")
+    require.NoError(t, err)
+    assert.Same(t, plugin, resolved)
+
+    // Registered services never reach the fallback; unknown ones still fail
+    _, err = container.Resolve("registered")
+    require.NoError(t, err)
+    _, err = container.Resolve("unknown")
+    assert.EqualError(t, err, "no service found for qualifier: unknown")
+
+    assert.Equal(t, []string{"plugin.greeter", "unknown"}, asked)
+}
@@ -0,0 +1,38 @@
+// pkg/container/freeze.go
+package container
+
+import (
+    "fmt"
+)
+
+// Freeze makes the container read-only once wiring is complete. Afterwards registrations,
+// decorations, imports, pools, proxies, custom scopes and metrics are rejected with an error.
+// Mutators without an error result panic instead, so wiring that happens after startup fails
+// loudly: PushOverride, the AddAspect methods, AddPostProcessor, SetProperty, WatchProperty,
+// SetFallbackResolver, SetTracer, SetQualifierPattern, SetParent, the injection settings and
+// the lifecycle hook and method name setters. Resolve, InjectStruct, Invoke and Cleanup keep
+// working, as do SetActiveProfiles and SetAspectGroupEnabled, which switch behaviour at runtime.
+// Reset unfreezes the container.
+func (c *Container) Freeze() {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    c.frozen = true
+    c.lifecycleManager.setFrozen(true)
+    c.log.Infow("Container frozen", "container", c.id, "services", len(c.services))
+}
+
+// IsFrozen reports whether Freeze has been called
+func (c *Container) IsFrozen() bool {
+    c.mu.RLock()
+    defer c.mu.RUnlock()
+    return c.frozen
+}
+
+// checkMutable rejects a mutating operation on a frozen container. The caller must hold the lock.
+func (c *Container) checkMutable(operation, qualifier string) error {
+    if !c.frozen {
+        return nil
+    }
+    c.log.Errorw("Container is frozen", "operation", operation, "qualifier", qualifier)
+    return fmt.Errorf("cannot %s %s: container is frozen", operation, qualifier)
+}
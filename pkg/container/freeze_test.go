@@ -0,0 +1,98 @@
+package container
+
+import (
+    "testing"
+
+    "di-extended/pkg/aop"
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+type frozenClient struct {
+    Service TestService `di:"testService" required:"true"`
+}
+
+func TestContainer_Freeze(t *testing.T) {
+    container := NewContainer()
+    service := &testServiceImpl{name: "test"}
+    require.NoError(t, container.Register("testService", service, Singleton))
+    require.NoError(t, container.RegisterPrototypeFactory("prototype", func() interface{} { return &TestStruct{} }))
+    container.Freeze()
+    assert.True(t, container.IsFrozen())
+
+    // Reads keep working
+    resolved, err := container.Resolve("testService")
+    require.NoError(t, err)
+    assert.Same(t, service, resolved)
+    client := &frozenClient{}
+    require.NoError(t, container.InjectStruct(client))
+    assert.Same(t, service, client.Service)
+
+    // Every kind of late wiring is rejected
+    tests := []struct {
+        name string
+        err  error
+    }{
+        {name: "Register", err: container.Register("late", &testServiceImpl{}, Singleton)},
+        {name: "RegisterPrototypeFactory", err: container.RegisterPrototypeFactory("late", func() interface{} { return &TestStruct{} })},
+        {name: "RegisterConstructor", err: container.RegisterConstructor("late", func() *TestStruct { return &TestStruct{} }, Singleton)},
+        {name: "Decorate", err: container.Decorate("testService", func(inner interface{}) interface{} { return inner })},
+        {name: "Import", err: container.Import(NewContainer(), false)},
+        {name: "EnablePool", err: container.EnablePool("prototype", 2)},
+        {name: "RegisterProxy", err: RegisterProxy[TestService](container, func(target TestService, _ InvokeFunc) TestService { return target })},
+        {name: "RegisterMetrics", err: container.RegisterMetrics(newFakeRegisterer())},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            assert.ErrorContains(t, tt.err, "container is frozen")
+        })
+    }
+    assert.PanicsWithError(t, "cannot override testService: container is frozen", func() {
+        container.PushOverride("testService", &testServiceImpl{})
+    })
+    aspect := &recordingAspect{kind: aop.Before, pointcut: ".*"}
+    assert.PanicsWithError(t, "cannot add aspect *container.recordingAspect: container is frozen", func() {
+        container.AddAspect(aspect)
+    })
+    assert.Panics(t, func() { container.AddAdvancedAspect(aop.NewMetricsAspect(".*")) })
+    assert.Panics(t, func() { container.AddAspectToGroup("audit", aspect) })
+    assert.PanicsWithError(t, "cannot add post-processor: container is frozen", func() {
+        container.AddPostProcessor(func(_ string, instance interface{}) (interface{}, error) { return instance, nil })
+    })
+
+    // Settings and hooks without an error result panic as well
+    settings := map[string]func(){
+        "SetProperty":               func() { container.SetProperty("env", "prod") },
+        "WatchProperty":             func() { container.WatchProperty("env", func(string) {}) },
+        "SetFallbackResolver":       func() { container.SetFallbackResolver(nil) },
+        "SetTracer":                 func() { container.SetTracer(nil) },
+        "SetQualifierPattern":       func() { container.SetQualifierPattern(nil) },
+        "SetParent":                 func() { container.SetParent(NewContainer()) },
+        "SetRequiredByDefault":      func() { container.SetRequiredByDefault(true) },
+        "AllowConvertibleInjection": func() { container.AllowConvertibleInjection(true) },
+        "SkipNonZeroFields":         func() { container.SkipNonZeroFields(true) },
+        "EnableAspectProxies":       func() { container.EnableAspectProxies(true) },
+        "SetInitMethodName":         func() { container.SetInitMethodName("Init") },
+        "SetDestroyMethodName":      func() { container.SetDestroyMethodName("Close") },
+        "AddPostConstructHook":      func() { container.GetLifecycleManager().AddPostConstructHook(LifecycleHook{Name: "late"}) },
+        "AddPreDestroyHook":         func() { container.GetLifecycleManager().AddPreDestroyHook(LifecycleHook{Name: "late"}) },
+    }
+    for name, set := range settings {
+        t.Run(name, func(t *testing.T) {
+            defer func() {
+                err, _ := recover().(error)
+                assert.ErrorContains(t, err, "container is frozen")
+            }()
+            set()
+        })
+    }
+    assert.PanicsWithError(t, "cannot set property env: container is frozen", settings["SetProperty"])
+    assert.PanicsWithError(t, "cannot add post-construct hook late: container is frozen", settings["AddPostConstructHook"])
+    assert.Empty(t, container.AspectSummary())
+    assert.False(t, container.Has("late"))
+
+    require.NoError(t, container.Reset())
+    assert.False(t, container.IsFrozen())
+    assert.NoError(t, container.Register("late", &testServiceImpl{}, Singleton))
+    assert.NotPanics(t, settings["AddPreDestroyHook"])
+}
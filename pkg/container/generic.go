@@ -0,0 +1,56 @@
+// pkg/container/generic.go
+package container
+
+import (
+    "fmt"
+    "reflect"
+)
+
+// TypeQualifier returns the qualifier the generic Register and Resolve use for T:
+// its import path and name, e.g. "di-extended/internal/services.UserService",
+// or the type literal for unnamed types such as "[]string".
+func TypeQualifier[T any]() string {
+    t := reflect.TypeOf((*T)(nil)).Elem()
+    if t.Name() == "" || t.PkgPath() == "" {
+        return t.String()
+    }
+    return t.PkgPath() + "." + t.Name()
+}
+
+// Register registers svc under the qualifier derived from T, so it can be resolved with
+// Resolve[T] without any string qualifier. T is usually an interface the service implements:
+//
+//     err := container.Register[services.UserService](c, services.NewUserService(), container.Singleton)
+func Register[T any](c *Container, svc T, scope Scope) error {
+    return c.Register(TypeQualifier[T](), svc, scope)
+}
+
+// RegisterPrototypeFactoryOf registers a prototype built by factory on every resolve, like
+// RegisterPrototypeFactory, but records T as the service type, so the prototype is found by
+// ResolveByType and collected into di:"all" fields:
+//
+//     err := container.RegisterPrototypeFactoryOf(c, "request", func() *Request { return &Request{} })
+func RegisterPrototypeFactoryOf[T any](c *Container, qualifier string, factory func() T) error {
+    if factory == nil {
+        c.log.Errorw("Cannot register nil factory", "qualifier", qualifier)
+        return fmt.Errorf("cannot register nil factory for qualifier: %s", qualifier)
+    }
+    return c.RegisterConstructor(qualifier, factory, Prototype)
+}
+
+// Resolve resolves the service registered for T with Register[T], including from parent
+// containers, and returns it as a T
+func Resolve[T any](c *Container) (T, error) {
+    var zero T
+    qualifier := TypeQualifier[T]()
+    instance, err := c.Resolve(qualifier)
+    if err != nil {
+        return zero, err
+    }
+    typed, ok := instance.(T)
+    if !ok {
+        c.log.Errorw("Resolved service has unexpected type", "qualifier", qualifier, "type", fmt.Sprintf("%T", instance))
+        return zero, fmt.Errorf("service %s of type %T is not a %s", qualifier, instance, qualifier)
+    }
+    return typed, nil
+}
@@ -0,0 +1,69 @@
+package container
+
+import (
+    "reflect"
+    "testing"
+
+    "di-extended/internal/services"
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+func TestGenericRegisterResolve(t *testing.T) {
+    c := NewContainer()
+    userService := services.NewUserService()
+    require.NoError(t, Register[services.UserService](c, userService, Singleton))
+    require.NoError(t, Register[notifier](c, &emailNotifier{}, Singleton))
+
+    resolved, err := Resolve[services.UserService](c)
+    require.NoError(t, err)
+    assert.Same(t, userService, resolved)
+    assert.Equal(t, "USER-7", resolved.GetUser(7))
+
+    n, err := Resolve[notifier](c)
+    require.NoError(t, err)
+    assert.Equal(t, "email: hi", n.Notify("hi"))
+
+    // Children see generic registrations of their parents
+    child := NewContainer()
+    child.SetParent(c)
+    _, err = Resolve[services.UserService](child)
+    assert.NoError(t, err)
+
+    assert.Equal(t, "di-extended/internal/services.UserService", TypeQualifier[services.UserService]())
+    assert.Equal(t, "[]string", TypeQualifier[[]string]())
+}
+
+func TestGenericResolveErrors(t *testing.T) {
+    c := NewContainer()
+
+    _, err := Resolve[notifier](c)
+    assert.ErrorContains(t, err, "no service found for qualifier: di-extended/pkg/container.notifier")
+
+    // A plain registration under the derived qualifier with the wrong type is reported
+    require.NoError(t, c.Register(TypeQualifier[commandHandler](), &TestStruct{}, Singleton))
+    _, err = Resolve[commandHandler](c)
+    assert.ErrorContains(t, err, "of type *container.TestStruct is not a di-extended/pkg/container.commandHandler")
+
+    var nilNotifier notifier
+    assert.Error(t, Register[notifier](c, nilNotifier, Singleton))
+}
+
+func TestRegisterPrototypeFactoryOf(t *testing.T) {
+    c := NewContainer()
+    require.NoError(t, RegisterPrototypeFactoryOf(c, "email", func() *emailNotifier { return &emailNotifier{} }))
+    require.NoError(t, c.RegisterPrototypeFactory("untyped", func() interface{} { return &emailNotifier{} }))
+
+    // The recorded type makes the prototype visible to type-based lookups
+    first, err := c.ResolveByType(reflect.TypeOf((*notifier)(nil)).Elem())
+    require.NoError(t, err)
+    assert.IsType(t, &emailNotifier{}, first)
+
+    var target struct {
+        Notifiers []notifier `di:"all"`
+    }
+    require.NoError(t, c.InjectStruct(&target))
+    assert.Len(t, target.Notifiers, 1, "the untyped factory stays invisible")
+
+    assert.Error(t, RegisterPrototypeFactoryOf[*emailNotifier](c, "nil", nil))
+}
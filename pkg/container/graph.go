@@ -0,0 +1,105 @@
+// pkg/container/graph.go
+package container
+
+import (
+    "fmt"
+    "reflect"
+)
+
+// WireGraph injects root and, recursively, every exported struct field without a di tag that
+// is itself injectable, i.e. whose type has di-tagged fields somewhere below it. Nil pointers to
+// such structs are allocated first. Nested structs are wired before the struct holding them, so
+// a lifecycle-aware root sees its whole graph in PostConstruct. Fields carrying a di tag are
+// filled by InjectStruct and not descended into, since they hold container-managed services.
+// Cycles are guarded: a struct reached twice is wired once, and a nil pointer whose type is
+// already being wired further up is left nil instead of being allocated endlessly.
+func (c *Container) WireGraph(root interface{}) error {
+    rootValue := reflect.ValueOf(root)
+    if rootValue.Kind() != reflect.Ptr || rootValue.IsNil() || rootValue.Elem().Kind() != reflect.Struct {
+        c.log.Errorw("Cannot wire graph", "type", fmt.Sprintf("%T", root))
+        return fmt.Errorf("root must be a non-nil pointer to struct, got: %T", root)
+    }
+
+    c.log.Infow("Wiring object graph", "root", rootValue.Elem().Type().Name())
+    return c.wireGraph(rootValue, make(map[graphNode]bool), make(map[reflect.Type]bool))
+}
+
+// graphNode identifies a struct in the graph; the type is needed because a struct and its
+// first field share an address
+type graphNode struct {
+    address uintptr
+    t       reflect.Type
+}
+
+// wireGraph wires the struct pointed to by ptr after its injectable fields. wired holds the
+// structs already handled and wiring the struct types on the current path.
+func (c *Container) wireGraph(ptr reflect.Value, wired map[graphNode]bool, wiring map[reflect.Type]bool) error {
+    structValue := ptr.Elem()
+    structType := structValue.Type()
+    node := graphNode{address: ptr.Pointer(), t: structType}
+    if wired[node] {
+        return nil
+    }
+    wired[node] = true
+
+    wiring[structType] = true
+    defer delete(wiring, structType)
+
+    for _, field := range injectionPlanFor(structType) {
+        if field.tagged || !field.exported {
+            continue
+        }
+        fieldValue := structValue.Field(field.index)
+        fieldType := fieldValue.Type()
+
+        switch {
+        case fieldType.Kind() == reflect.Struct && isInjectable(fieldType, make(map[reflect.Type]bool)):
+            if err := c.wireGraph(fieldValue.Addr(), wired, wiring); err != nil {
+                return err
+            }
+        case fieldType.Kind() == reflect.Ptr && fieldType.Elem().Kind() == reflect.Struct &&
+            isInjectable(fieldType.Elem(), make(map[reflect.Type]bool)):
+            if fieldValue.IsNil() {
+                if wiring[fieldType.Elem()] {
+                    c.log.Debugw("Leaving recursive field nil", "field", field.name, "type", fieldType)
+                    continue
+                }
+                fieldValue.Set(reflect.New(fieldType.Elem()))
+            }
+            if err := c.wireGraph(fieldValue, wired, wiring); err != nil {
+                return err
+            }
+        }
+    }
+
+    if err := c.InjectStruct(ptr.Interface()); err != nil {
+        return fmt.Errorf("failed to wire %s: %w", structType.Name(), err)
+    }
+    return nil
+}
+
+// isInjectable reports whether a struct type has a di-tagged field, directly or in an
+// untagged struct field below it; seen guards against recursive types
+func isInjectable(t reflect.Type, seen map[reflect.Type]bool) bool {
+    if seen[t] {
+        return false
+    }
+    seen[t] = true
+
+    for _, field := range injectionPlanFor(t) {
+        if field.tagged {
+            return true
+        }
+        if !field.exported {
+            continue
+        }
+        fieldType := t.Field(field.index).Type
+        if fieldType.Kind() == reflect.Ptr {
+            fieldType = fieldType.Elem()
+        }
+        if fieldType.Kind() == reflect.Struct && isInjectable(fieldType, seen) {
+            return true
+        }
+    }
+    return false
+}
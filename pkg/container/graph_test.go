@@ -0,0 +1,78 @@
+package container
+
+import (
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+type graphRepository struct {
+    Service TestService `di:"testService" required:"true"`
+}
+
+type graphHandler struct {
+    Repository *graphRepository
+    Audit      graphRepository
+}
+
+type graphLink struct {
+    Service TestService `di:"testService"`
+    Next    *graphLink
+}
+
+type graphConfig struct {
+    Name string
+}
+
+type graphRoot struct {
+    Service TestService `di:"testService"`
+    Handler *graphHandler
+    Node    *graphLink
+    Config  *graphConfig
+}
+
+func TestContainer_WireGraph(t *testing.T) {
+    container := NewContainer()
+    service := &testServiceImpl{}
+    require.NoError(t, container.Register("testService", service, Singleton))
+
+    root := &graphRoot{}
+    require.NoError(t, container.WireGraph(root))
+
+    assert.Same(t, service, root.Service)
+    require.NotNil(t, root.Handler)
+    require.NotNil(t, root.Handler.Repository)
+    assert.Same(t, service, root.Handler.Repository.Service)
+    assert.Same(t, service, root.Handler.Audit.Service)
+
+    // A recursive type is allocated once, not endlessly
+    require.NotNil(t, root.Node)
+    assert.Same(t, service, root.Node.Service)
+    assert.Nil(t, root.Node.Next)
+
+    // Structs without di tags are not allocated
+    assert.Nil(t, root.Config)
+}
+
+func TestContainer_WireGraphCycle(t *testing.T) {
+    container := NewContainer()
+    require.NoError(t, container.Register("testService", &testServiceImpl{}, Singleton))
+
+    first := &graphLink{}
+    second := &graphLink{Next: first}
+    first.Next = second
+    require.NoError(t, container.WireGraph(first))
+    assert.NotNil(t, first.Service)
+    assert.NotNil(t, second.Service)
+}
+
+func TestContainer_WireGraphErrors(t *testing.T) {
+    container := NewContainer()
+
+    err := container.WireGraph(&graphRoot{})
+    assert.ErrorContains(t, err, "failed to wire graphRepository: required service not found for field Service")
+
+    assert.Error(t, container.WireGraph(graphRoot{}))
+    assert.Error(t, container.WireGraph((*graphRoot)(nil)))
+}
@@ -0,0 +1,112 @@
+// pkg/container/import.go
+package container
+
+import (
+    "errors"
+    "fmt"
+    "sort"
+    "strings"
+)
+
+// Import copies every registration of other into this container, so modules can each build
+// their own container and be composed into one. Built singletons are shared by reference;
+// lazy singletons are built in other first so both containers hand out the same instance.
+// Shared instances stay owned by other: this container never runs PreDestroy on them, so
+// they are destroyed once, by other's Cleanup. Conditional services are copied unbuilt and
+// evaluate their condition against this container; those registered by instance hand out the
+// instance of other, so this container never runs PostConstruct or PreDestroy on them either.
+// When a qualifier already exists here, Import fails without importing anything unless
+// overwrite is set; the overwritten services are then pre-destroyed like in Cleanup.
+func (c *Container) Import(other *Container, overwrite bool) error {
+    if other == nil || other == c {
+        c.log.Errorw("Invalid import source", "container", c.id)
+        return fmt.Errorf("cannot import from a nil container or the container itself")
+    }
+
+    other.mu.RLock()
+    qualifiers := make([]string, 0, len(other.services))
+    for qualifier := range other.services {
+        qualifiers = append(qualifiers, qualifier)
+    }
+    sort.Slice(qualifiers, func(i, j int) bool {
+        return other.services[qualifiers[i]].order < other.services[qualifiers[j]].order
+    })
+    pendingBuild := make([]string, 0)
+    for _, qualifier := range qualifiers {
+        service := other.services[qualifier]
+        if service.Scope.caches() && service.Instance == nil && service.lazy && service.Condition == nil {
+            pendingBuild = append(pendingBuild, qualifier)
+        }
+    }
+    other.mu.RUnlock()
+
+    for _, qualifier := range pendingBuild {
+        if _, err := other.Resolve(qualifier); err != nil {
+            c.log.Errorw("Cannot build service for import", "qualifier", qualifier, "error", err)
+            return fmt.Errorf("cannot import %s: %w", qualifier, err)
+        }
+    }
+
+    other.mu.RLock()
+    imported := make([]*ScopedService, len(qualifiers))
+    for i, qualifier := range qualifiers {
+        copied := *other.services[qualifier]
+        copied.Dependencies = append([]string(nil), copied.Dependencies...)
+        // A conditional singleton registered by instance would hand out that same instance here
+        if copied.Instance != nil || copied.shared {
+            copied.external = true
+        }
+        imported[i] = &copied
+    }
+    other.mu.RUnlock()
+
+    c.mu.Lock()
+    if err := c.checkMutable("import", fmt.Sprintf("from container %d", other.id)); err != nil {
+        c.mu.Unlock()
+        return err
+    }
+    collisions := make([]string, 0)
+    for _, qualifier := range qualifiers {
+        if _, exists := c.services[qualifier]; exists {
+            collisions = append(collisions, qualifier)
+        }
+    }
+    if len(collisions) > 0 && !overwrite {
+        c.mu.Unlock()
+        c.log.Errorw("Import collides with existing services", "qualifiers", collisions)
+        return fmt.Errorf("cannot import from container %d, qualifiers already registered: %s",
+            other.id, strings.Join(collisions, ", "))
+    }
+    if len(collisions) > 0 {
+        c.log.Warnw("Import overwrites existing services", "qualifiers", collisions)
+    }
+
+    replaced := make(map[string]*ScopedService, len(collisions))
+    for _, qualifier := range collisions {
+        replaced[qualifier] = c.services[qualifier]
+        c.discardPool(qualifier)
+    }
+    for i, qualifier := range qualifiers {
+        c.store(qualifier, imported[i])
+    }
+    c.mu.Unlock()
+
+    c.log.Infow("Imported services",
+        "from", other.id,
+        "into", c.id,
+        "count", len(qualifiers))
+
+    // Overwritten instances are destroyed without the lock, so PreDestroy may call back in
+    var errs []error
+    for _, qualifier := range collisions {
+        service := replaced[qualifier]
+        if !service.Scope.caches() || service.Instance == nil || service.external {
+            continue
+        }
+        if err := c.preDestroy(qualifier, service.Scope, service.destroyable()); err != nil {
+            c.log.Errorw("Pre-destroy of overwritten service failed", "qualifier", qualifier, "error", err)
+            errs = append(errs, err)
+        }
+    }
+    return errors.Join(errs...)
+}
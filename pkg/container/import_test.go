@@ -0,0 +1,158 @@
+package container
+
+import (
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+func TestContainer_Import(t *testing.T) {
+    module := NewContainer()
+    shared := &testServiceImpl{name: "shared"}
+    require.NoError(t, module.Register("testService", shared, Singleton))
+    require.NoError(t, module.RegisterPrototypeFactory("prototype", func() interface{} {
+        return &TestStruct{}
+    }))
+
+    app := NewContainer()
+    require.NoError(t, app.Register("local", &TestStruct{}, Singleton))
+    require.NoError(t, app.Import(module, false))
+
+    // Singletons are shared with the module, not rebuilt
+    resolved, err := app.Resolve("testService")
+    require.NoError(t, err)
+    assert.Same(t, shared, resolved)
+
+    first, err := app.Resolve("prototype")
+    require.NoError(t, err)
+    second, err := app.Resolve("prototype")
+    require.NoError(t, err)
+    assert.NotSame(t, first, second)
+
+    _, err = app.Resolve("local")
+    assert.NoError(t, err)
+}
+
+func TestContainer_ImportCollisions(t *testing.T) {
+    module := NewContainer()
+    replacement := &testServiceImpl{name: "module"}
+    require.NoError(t, module.Register("testService", replacement, Singleton))
+    require.NoError(t, module.Register("other", &TestStruct{}, Singleton))
+
+    app := NewContainer()
+    original := &testServiceImpl{name: "app"}
+    require.NoError(t, app.Register("testService", original, Singleton))
+
+    err := app.Import(module, false)
+    assert.ErrorContains(t, err, "qualifiers already registered: testService")
+
+    // Nothing was imported by the failed attempt
+    _, err = app.Resolve("other")
+    assert.Error(t, err)
+    resolved, err := app.Resolve("testService")
+    require.NoError(t, err)
+    assert.Same(t, original, resolved)
+
+    require.NoError(t, app.Import(module, true))
+    resolved, err = app.Resolve("testService")
+    require.NoError(t, err)
+    assert.Same(t, replacement, resolved)
+
+    assert.Error(t, app.Import(app, false))
+    assert.Error(t, app.Import(nil, false))
+}
+
+func TestContainer_ImportLazySingleton(t *testing.T) {
+    module := NewContainer()
+    require.NoError(t, module.Register("weak", &testServiceImpl{name: "weak"}, WeakSingleton))
+
+    app := NewContainer()
+    require.NoError(t, app.Import(module, false))
+
+    fromModule, err := module.Resolve("weak")
+    require.NoError(t, err)
+    fromApp, err := app.Resolve("weak")
+    require.NoError(t, err)
+    assert.Same(t, fromModule, fromApp)
+}
+
+func TestContainer_ImportDestroysSharedInstancesOnce(t *testing.T) {
+    module := NewContainer()
+    shared := &testServiceImpl{name: "shared"}
+    require.NoError(t, module.Register("testService", shared, Singleton))
+    weak := &testServiceImpl{name: "weak"}
+    require.NoError(t, module.Register("weak", weak, WeakSingleton))
+
+    app := NewContainer()
+    require.NoError(t, app.Import(module, false))
+    fromApp, err := app.Resolve("weak")
+    require.NoError(t, err)
+
+    // The importing container does not own the shared instances
+    require.NoError(t, app.Evict("weak"))
+    require.NoError(t, app.Cleanup())
+    assert.False(t, shared.destroyed)
+    assert.False(t, fromApp.(*testServiceImpl).destroyed)
+
+    require.NoError(t, module.Cleanup())
+    assert.True(t, shared.destroyed)
+    assert.True(t, fromApp.(*testServiceImpl).destroyed)
+
+    // An instance rebuilt after eviction belongs to the importing container
+    rebuilt, err := app.Resolve("weak")
+    require.NoError(t, err)
+    assert.NotSame(t, fromApp, rebuilt)
+    require.NoError(t, app.Cleanup())
+    assert.True(t, rebuilt.(*testServiceImpl).destroyed)
+}
+
+func TestContainer_ImportOverwriteDestroysReplaced(t *testing.T) {
+    module := NewContainer()
+    require.NoError(t, module.Register("testService", &testServiceImpl{name: "module"}, Singleton))
+
+    app := NewContainer()
+    original := &testServiceImpl{name: "app"}
+    require.NoError(t, app.Register("testService", original, Singleton))
+
+    require.NoError(t, app.Import(module, true))
+    assert.True(t, original.destroyed)
+}
+
+// lifecycleCounter counts how often the lifecycle runs on one instance
+type lifecycleCounter struct {
+    postConstructs int
+    preDestroys    int
+}
+
+func (l *lifecycleCounter) PostConstruct() error {
+    l.postConstructs++
+    return nil
+}
+
+func (l *lifecycleCounter) PreDestroy() error {
+    l.preDestroys++
+    return nil
+}
+
+func TestContainer_ImportConditionalInstance(t *testing.T) {
+    module := NewContainer()
+    counter := &lifecycleCounter{}
+    always := func(*Container) bool { return true }
+    require.NoError(t, module.RegisterIf("counter", counter, Singleton, always))
+
+    app := NewContainer()
+    require.NoError(t, app.Import(module, false))
+
+    fromApp, err := app.Resolve("counter")
+    require.NoError(t, err)
+    fromModule, err := module.Resolve("counter")
+    require.NoError(t, err)
+    assert.Same(t, fromModule, fromApp)
+
+    // The instance belongs to module, so only module runs its lifecycle
+    require.NoError(t, app.Cleanup())
+    require.NoError(t, module.Cleanup())
+    assert.Equal(t, 1, counter.postConstructs)
+    assert.Equal(t, 1, counter.preDestroys)
+}
@@ -0,0 +1,29 @@
+// pkg/container/inject_trace.go
+package container
+
+import (
+    "fmt"
+)
+
+// injectionTrace collects the field decisions of one InjectStructTraced call.
+// A nil trace records nothing, so plain InjectStruct pays no cost.
+type injectionTrace struct {
+    entries []string
+}
+
+// add records one decision
+func (t *injectionTrace) add(format string, args ...interface{}) {
+    if t == nil {
+        return
+    }
+    t.entries = append(t.entries, fmt.Sprintf(format, args...))
+}
+
+// InjectStructTraced injects like InjectStruct and also returns, in field order, what happened
+// to each field, e.g. "inject Repo <- userRepo" or "skip cache (cacheService): unexported".
+// On failure the trace covers the fields handled before the error.
+func (c *Container) InjectStructTraced(target interface{}) ([]string, error) {
+    trace := &injectionTrace{entries: make([]string, 0)}
+    err := c.injectStruct(target, trace, false)
+    return trace.entries, err
+}
@@ -0,0 +1,36 @@
+package container
+
+import (
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+type tracedTarget struct {
+    Service  TestService `di:"testService" required:"true"`
+    Optional TestService `di:"optionalService"`
+    Name     string
+    hidden   TestService `di:"testService"`
+}
+
+func TestContainer_InjectStructTraced(t *testing.T) {
+    container := NewContainer()
+    require.NoError(t, container.Register("testService", &testServiceImpl{name: "test"}, Singleton))
+
+    target := &tracedTarget{}
+    trace, err := container.InjectStructTraced(target)
+    require.NoError(t, err)
+    assert.NotNil(t, target.Service)
+    assert.Equal(t, []string{
+        "inject Service <- testService",
+        "skip Optional (optionalService): optional service not found",
+        "skip Name: no di tag",
+        "skip hidden (testService): unexported",
+    }, trace)
+
+    // A failure returns the trace up to the failing field
+    trace, err = NewContainer().InjectStructTraced(&tracedTarget{})
+    assert.ErrorContains(t, err, "required service not found for field Service")
+    assert.Empty(t, trace)
+}
@@ -0,0 +1,89 @@
+// pkg/container/injection_cache.go
+package container
+
+import (
+    "reflect"
+    "strings"
+    "sync"
+)
+
+// injectionField is the parsed injection metadata of a single struct field
+type injectionField struct {
+    index     int               // Field index within the struct
+    name      string            // Field name, for logging and errors
+    qualifier string            // Value of the di tag
+    tagged    bool              // Whether the field carries a di tag at all
+    exported  bool              // Whether the field is exported and therefore settable
+    required  bool              // Whether the field is tagged required:"true"
+    optional  bool              // Whether the field is tagged required:"false"
+    profiles  []string          // Profiles from the profile tag; the field is injected only if one is active
+    all       bool              // Slice field tagged di:"all", filled with every service of its element type
+    prefix    string            // Qualifier prefix of a map field tagged di:"prefix:...", keyed by the rest of the qualifier
+}
+
+// isRequired reports whether the field must be resolvable. Fields without a required tag
+// follow requiredByDefault; an explicit required tag always wins.
+func (f injectionField) isRequired(requiredByDefault bool) bool {
+    return f.required || (requiredByDefault && !f.optional)
+}
+
+// injectionPlans caches the parsed fields per struct type so repeated
+// InjectStruct calls on the same type skip the tag walk. A plan holds only what the type and
+// its tags determine; container settings such as SetRequiredByDefault, SkipNonZeroFields, the
+// lifecycle method names or properties are read on every call, so a plan can never go stale
+// and is shared by all containers.
+var injectionPlans sync.Map // map[reflect.Type][]injectionField
+
+// injectionPlanFor returns the cached injection plan for a struct type, building it on first use
+func injectionPlanFor(t reflect.Type) []injectionField {
+    if plan, ok := injectionPlans.Load(t); ok {
+        return plan.([]injectionField)
+    }
+
+    plan := make([]injectionField, t.NumField())
+    for i := 0; i < t.NumField(); i++ {
+        field := t.Field(i)
+        qualifier, tagged := field.Tag.Lookup("di")
+        plan[i] = injectionField{
+            index:     i,
+            name:      field.Name,
+            qualifier: qualifier,
+            tagged:    tagged,
+            exported:  field.PkgPath == "",
+            required:  field.Tag.Get("required") == "true",
+            optional:  field.Tag.Get("required") == "false",
+            profiles:  splitTagList(field.Tag.Get("profile")),
+            all:       qualifier == allQualifier && field.Type.Kind() == reflect.Slice,
+            prefix:    prefixOf(qualifier, field.Type),
+        }
+    }
+
+    actual, _ := injectionPlans.LoadOrStore(t, plan)
+    return actual.([]injectionField)
+}
+
+// prefixOf returns the qualifier prefix of a di:"prefix:..." tag on a map with string keys
+func prefixOf(qualifier string, t reflect.Type) string {
+    if t.Kind() != reflect.Map || t.Key().Kind() != reflect.String {
+        return ""
+    }
+    prefix, _ := strings.CutPrefix(qualifier, prefixTag)
+    if prefix == qualifier {
+        return ""
+    }
+    return prefix
+}
+
+// splitTagList splits a comma-separated tag value, dropping blanks
+func splitTagList(value string) []string {
+    if value == "" {
+        return nil
+    }
+    items := make([]string, 0)
+    for _, item := range strings.Split(value, ",") {
+        if item = strings.TrimSpace(item); item != "" {
+            items = append(items, item)
+        }
+    }
+    return items
+}
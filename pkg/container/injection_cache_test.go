@@ -0,0 +1,93 @@
+package container
+
+import (
+    "reflect"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+    "go.uber.org/zap"
+)
+
+func TestInjectionPlanCache(t *testing.T) {
+    container := NewContainer()
+    testService := &testServiceImpl{name: "test"}
+    require.NoError(t, container.Register("testService", testService, Singleton))
+
+    structType := reflect.TypeOf(TestStruct{})
+    injectionPlans.Delete(structType)
+
+    // First injection builds and caches the plan
+    first := &TestStruct{}
+    require.NoError(t, container.InjectStruct(first))
+    cached, ok := injectionPlans.Load(structType)
+    require.True(t, ok)
+
+    plan := cached.([]injectionField)
+    require.Len(t, plan, structType.NumField())
+    assert.Equal(t, "testService", plan[0].qualifier)
+    assert.True(t, plan[0].tagged)
+    assert.False(t, plan[2].tagged) // NoTag
+
+    // Second injection reuses the plan with identical results
+    second := &TestStruct{}
+    require.NoError(t, container.InjectStruct(second))
+    assert.Equal(t, testService, second.Service)
+    assert.Nil(t, second.Optional)
+    assert.Nil(t, second.NoTag)
+    assert.Nil(t, second.private)
+    assert.Equal(t, first, second)
+}
+
+type cachedPlanTarget struct {
+    Service TestService `di:"testService"`
+    Clock   TestService `di:"clock"`
+}
+
+func TestInjectionPlanCacheFollowsSettings(t *testing.T) {
+    container := NewContainer()
+    require.NoError(t, container.Register("testService", &testServiceImpl{name: "test"}, Singleton))
+
+    // The plan is cached by the first injection, before any setting changes
+    require.NoError(t, container.InjectStruct(&cachedPlanTarget{}))
+    _, cached := injectionPlans.Load(reflect.TypeOf(cachedPlanTarget{}))
+    require.True(t, cached)
+
+    // Settings changed afterwards still apply to the cached plan
+    container.SetRequiredByDefault(true)
+    assert.ErrorContains(t, container.InjectStruct(&cachedPlanTarget{}), "clock")
+    container.SetRequiredByDefault(false)
+
+    preset := &testServiceImpl{name: "preset"}
+    container.SkipNonZeroFields(true)
+    target := &cachedPlanTarget{Service: preset}
+    require.NoError(t, container.InjectStruct(target))
+    assert.Same(t, preset, target.Service)
+}
+
+func benchmarkInjectStruct(b *testing.B, cold bool) {
+    container := NewContainer()
+    container.log = zap.NewNop().Sugar()
+    if err := container.Register("testService", &testServiceImpl{name: "bench"}, Singleton); err != nil {
+        b.Fatal(err)
+    }
+    structType := reflect.TypeOf(TestStruct{})
+
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        if cold {
+            injectionPlans.Delete(structType)
+        }
+        if err := container.InjectStruct(&TestStruct{}); err != nil {
+            b.Fatal(err)
+        }
+    }
+}
+
+func BenchmarkInjectStructCold(b *testing.B) {
+    benchmarkInjectStruct(b, true)
+}
+
+func BenchmarkInjectStructWarm(b *testing.B) {
+    benchmarkInjectStruct(b, false)
+}
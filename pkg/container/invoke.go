@@ -0,0 +1,250 @@
+// pkg/container/invoke.go
+package container
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "reflect"
+    "di-extended/pkg/aop"
+)
+
+var (
+    errorType   = reflect.TypeOf((*error)(nil)).Elem()
+    contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+)
+
+// Invoke calls a method on target by name, running every matching aspect around it.
+// Before and Around advice run ahead of the method, After advice always runs afterwards,
+// followed by AfterReturning or AfterThrowing depending on the returned error.
+// Before or Around advice may abort the call (see aop.ErrAbort): the method is skipped and only
+// After advice runs. Around aspects implementing aop.WrappingAspect nest around the method call.
+// The method's return values are captured in jp.ReturnVals before any After advice runs, so
+// AfterReturning advice can read the real results, e.g. to cache them.
+// The method's return values are returned as-is; a non-nil trailing error is returned as the error.
+func (c *Container) Invoke(target interface{}, methodName string, args ...interface{}) ([]interface{}, error) {
+    return c.invoke(nil, target, methodName, args)
+}
+
+// InvokeCtx is like Invoke but propagates ctx through the join point as jp.Ctx.
+// Aspects may replace jp.Ctx with a derived context (e.g. a child span); if the method's
+// first parameter is a context.Context it receives the final jp.Ctx and args supply the rest.
+func (c *Container) InvokeCtx(ctx context.Context, target interface{}, methodName string, args ...interface{}) ([]interface{}, error) {
+    if ctx == nil {
+        ctx = context.Background()
+    }
+    return c.invoke(ctx, target, methodName, args)
+}
+
+// invoke implements Invoke and InvokeCtx; a nil ctx disables context propagation
+func (c *Container) invoke(ctx context.Context, target interface{}, methodName string, args []interface{}) ([]interface{}, error) {
+    if target == nil {
+        c.log.Errorw("Cannot invoke method on nil target", "method", methodName)
+        return nil, fmt.Errorf("cannot invoke method %s on nil target", methodName)
+    }
+
+    targetValue := reflect.ValueOf(target)
+    method, ok := targetValue.Type().MethodByName(methodName)
+    if !ok {
+        c.log.Errorw("Method not found",
+            "target", fmt.Sprintf("%T", target),
+            "method", methodName)
+        return nil, fmt.Errorf("method %s not found on %T", methodName, target)
+    }
+
+    // The container supplies the context argument itself when the method takes one first
+    passCtx := ctx != nil && method.Type.NumIn() > 1 && method.Type.In(1) == contextType
+    skip := 0
+    if passCtx {
+        skip = 1
+    }
+
+    in, err := buildCallArgs(method, targetValue, skip, args)
+    if err != nil {
+        c.log.Errorw("Invalid method arguments",
+            "target", fmt.Sprintf("%T", target),
+            "method", methodName,
+            "error", err)
+        return nil, err
+    }
+
+    jp := &aop.JoinPoint{
+        Target: target,
+        Method: method,
+        Args:   args,
+        Ctx:    ctx,
+    }
+
+    c.mu.RLock()
+    aspects := c.aspectManager.GetMatchingAspects(jp)
+    c.mu.RUnlock()
+
+    c.log.Debugw("Invoking method",
+        "signature", jp.Signature(),
+        "aspects", len(aspects))
+
+    wrappers, aspects := splitWrapping(aspects)
+    if err := c.runAdvice(aspects, jp, aop.Before, aop.Around); err != nil {
+        return nil, err
+    }
+
+    if jp.Abort {
+        return c.abortInvocation(aspects, jp)
+    }
+
+    called := false
+    proceed := func() error {
+        if passCtx {
+            if jp.Ctx == nil {
+                in[1] = reflect.Zero(contextType)
+            } else {
+                in[1] = reflect.ValueOf(jp.Ctx)
+            }
+        }
+
+        called = true
+        results := method.Func.Call(in)
+        jp.ReturnVals = make([]interface{}, len(results))
+        for i, result := range results {
+            jp.ReturnVals[i] = result.Interface()
+        }
+        if n := len(results); n > 0 && method.Type.Out(n-1) == errorType && !results[n-1].IsNil() {
+            jp.Error = results[n-1].Interface().(error)
+        }
+        return nil
+    }
+    // The first wrapping aspect ends up outermost
+    for i := len(wrappers) - 1; i >= 0; i-- {
+        wrapper, next := wrappers[i], proceed
+        proceed = func() error { return c.wrapAdvice(wrapper, jp, next) }
+    }
+    if err := proceed(); err != nil {
+        return jp.ReturnVals, err
+    }
+    if !called {
+        return c.abortInvocation(aspects, jp)
+    }
+
+    if err := c.runAdvice(aspects, jp, aop.After); err != nil {
+        return jp.ReturnVals, err
+    }
+    if jp.Error != nil {
+        if err := c.runAdvice(aspects, jp, aop.AfterThrowing); err != nil {
+            return jp.ReturnVals, err
+        }
+        return jp.ReturnVals, jp.Error
+    }
+    if err := c.runAdvice(aspects, jp, aop.AfterReturning); err != nil {
+        return jp.ReturnVals, err
+    }
+
+    return jp.ReturnVals, nil
+}
+
+// splitWrapping separates the wrapping Around aspects from the others, keeping their order
+func splitWrapping(aspects []aop.Aspect) (wrappers []aop.WrappingAspect, others []aop.Aspect) {
+    others = make([]aop.Aspect, 0, len(aspects))
+    for _, aspect := range aspects {
+        if aop.IsWrapping(aspect) {
+            wrappers = append(wrappers, aspect.(aop.WrappingAspect))
+            continue
+        }
+        others = append(others, aspect)
+    }
+    return wrappers, others
+}
+
+// wrapAdvice runs a wrapping aspect around next inside a span, converting its failure
+// like any other advice error
+func (c *Container) wrapAdvice(wrapper aop.WrappingAspect, jp *aop.JoinPoint, next func() error) error {
+    _, end := c.startSpan(jp.Ctx, fmt.Sprintf("di.aspect %T %s", wrapper, jp.Signature()))
+    err := wrapper.Wrap(jp, next)
+    end(err)
+    if err != nil {
+        return c.adviceError(wrapper, jp, err)
+    }
+    return nil
+}
+
+// abortInvocation completes a join point aborted by advice: the method is not called,
+// missing return values are filled with zero values and After advice runs
+func (c *Container) abortInvocation(aspects []aop.Aspect, jp *aop.JoinPoint) ([]interface{}, error) {
+    c.log.Infow("Method call aborted by aspect", "signature", jp.Signature())
+
+    if jp.ReturnVals == nil {
+        methodType := jp.Method.Type
+        jp.ReturnVals = make([]interface{}, methodType.NumOut())
+        for i := range jp.ReturnVals {
+            jp.ReturnVals[i] = reflect.Zero(methodType.Out(i)).Interface()
+        }
+    }
+    if err := c.runAdvice(aspects, jp, aop.After); err != nil {
+        return jp.ReturnVals, err
+    }
+    return jp.ReturnVals, jp.Error
+}
+
+// buildCallArgs prepends the receiver and zero placeholders for the first skip
+// parameters to the validated argument values
+func buildCallArgs(method reflect.Method, receiver reflect.Value, skip int, args []interface{}) ([]reflect.Value, error) {
+    values, err := aop.BuildArgs(method, skip, args)
+    if err != nil {
+        return nil, err
+    }
+
+    in := make([]reflect.Value, 0, len(values)+skip+1)
+    in = append(in, receiver)
+    for i := 0; i < skip; i++ {
+        in = append(in, reflect.Zero(method.Type.In(i+1)))
+    }
+    return append(in, values...), nil
+}
+
+// runAdvice executes the advice of every aspect whose kind is one of kinds, stopping early
+// when an advice aborts the join point. AfterThrowing advice with an aop.ErrorTypeFilter only
+// runs for matching errors.
+func (c *Container) runAdvice(aspects []aop.Aspect, jp *aop.JoinPoint, kinds ...aop.AspectKind) error {
+    for _, aspect := range aspects {
+        for _, kind := range kinds {
+            if aspect.Kind() != kind {
+                continue
+            }
+            if kind == aop.AfterThrowing && !aop.HandlesError(aspect, jp.Error) {
+                c.log.Debugw("Skipping after throwing advice for unhandled error type",
+                    "aspect", fmt.Sprintf("%T", aop.Underlying(aspect)),
+                    "signature", jp.Signature(),
+                    "error", jp.Error)
+                continue
+            }
+            aborted := jp.Abort
+            err := c.tracedAdvice(aspect, jp)
+            if errors.Is(err, aop.ErrAbort) {
+                jp.Abort, err = true, nil
+            }
+            if err != nil {
+                if err := c.adviceError(aspect, jp, err); err != nil {
+                    return err
+                }
+            }
+            // An aspect aborting the join point ends the current phase
+            if jp.Abort && !aborted {
+                return nil
+            }
+        }
+    }
+    return nil
+}
+
+// adviceError converts a failed advice into the error returned to the caller.
+// Failures of SoftFail aspects are logged and swallowed so the chain continues.
+func (c *Container) adviceError(aspect aop.Aspect, jp *aop.JoinPoint, err error) error {
+    if aop.IsSoftFail(aspect) {
+        c.log.Warnw("Soft-fail aspect failed, continuing",
+            "aspect", fmt.Sprintf("%T", aop.Underlying(aspect)),
+            "kind", aspect.Kind().String(),
+            "signature", jp.Signature(),
+            "error", err)
+        return nil
+    }
+    return fmt.Errorf("%s aspect failed: %w", aspect.Kind(), err)
+}
@@ -0,0 +1,413 @@
+package container
+
+import (
+    "context"
+    "fmt"
+    "reflect"
+    "testing"
+
+    "di-extended/internal/services"
+    "di-extended/pkg/aop"
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+    "go.uber.org/zap"
+    "go.uber.org/zap/zapcore"
+    "go.uber.org/zap/zaptest/observer"
+)
+
+func TestContainer_Invoke(t *testing.T) {
+    container := NewContainer()
+    core, logs := observer.New(zapcore.InfoLevel)
+    container.AddAspect(&services.LoggingAspect{Log: zap.New(core).Sugar()})
+
+    userService := services.NewUserService()
+
+    results, err := container.Invoke(userService, "GetUser", 123)
+    require.NoError(t, err)
+    require.Len(t, results, 1)
+    assert.Equal(t, "USER-123", results[0])
+
+    // Verify the logging aspect fired for the call
+    calls := logs.FilterMessage("Method call").All()
+    require.Len(t, calls, 1)
+    assert.Equal(t, "GetUser", calls[0].ContextMap()["method"])
+}
+
+func TestContainer_InvokeErrors(t *testing.T) {
+    container := NewContainer()
+    userService := services.NewUserService()
+
+    tests := []struct {
+        name   string
+        target interface{}
+        method string
+        args   []interface{}
+    }{
+        {
+            name:   "nil target",
+            target: nil,
+            method: "GetUser",
+            args:   []interface{}{1},
+        },
+        {
+            name:   "unknown method",
+            target: userService,
+            method: "DeleteUser",
+            args:   []interface{}{1},
+        },
+        {
+            name:   "wrong arity",
+            target: userService,
+            method: "GetUser",
+            args:   []interface{}{1, 2},
+        },
+        {
+            name:   "wrong argument type",
+            target: userService,
+            method: "GetUser",
+            args:   []interface{}{"1"},
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            results, err := container.Invoke(tt.target, tt.method, tt.args...)
+            assert.Error(t, err)
+            assert.Nil(t, results)
+        })
+    }
+}
+
+type ctxKey string
+
+type tracedService struct{}
+
+func (s *tracedService) Handle(ctx context.Context, name string) string {
+    return fmt.Sprintf("%v:%s", ctx.Value(ctxKey("span")), name)
+}
+
+type tracingAspect struct {
+    seenTraceID interface{}
+}
+
+func (a *tracingAspect) Kind() aop.AspectKind { return aop.Before }
+func (a *tracingAspect) PointCut() string     { return "tracedService.*" }
+
+func (a *tracingAspect) Advice(jp *aop.JoinPoint) error {
+    a.seenTraceID = jp.Ctx.Value(ctxKey("trace"))
+    jp.Ctx = context.WithValue(jp.Ctx, ctxKey("span"), "child-span")
+    return nil
+}
+
+func TestContainer_InvokeCtx(t *testing.T) {
+    container := NewContainer()
+    aspect := &tracingAspect{}
+    container.AddAspect(aspect)
+
+    ctx := context.WithValue(context.Background(), ctxKey("trace"), "trace-123")
+    results, err := container.InvokeCtx(ctx, &tracedService{}, "Handle", "order")
+    require.NoError(t, err)
+
+    // The aspect read the propagated context and the method received the derived one
+    assert.Equal(t, "trace-123", aspect.seenTraceID)
+    require.Len(t, results, 1)
+    assert.Equal(t, "child-span:order", results[0])
+}
+
+type phaseRecordingAspect struct {
+    aop.BaseAdvancedAspect
+    service *phaseService
+    events  []string
+}
+
+func (a *phaseRecordingAspect) PointCut() string { return "phaseService.*" }
+
+func (a *phaseRecordingAspect) Before(jp *aop.JoinPoint) error {
+    a.events = append(a.events, fmt.Sprintf("before calls=%d", a.service.calls))
+    return nil
+}
+
+func (a *phaseRecordingAspect) After(jp *aop.JoinPoint) error {
+    a.events = append(a.events, fmt.Sprintf("after calls=%d returned=%v", a.service.calls, jp.ReturnVals))
+    return nil
+}
+
+type phaseService struct {
+    calls int
+}
+
+func (s *phaseService) Run() string {
+    s.calls++
+    return "done"
+}
+
+func TestContainer_InvokeAdvancedAspect(t *testing.T) {
+    container := NewContainer()
+    service := &phaseService{}
+    aspect := &phaseRecordingAspect{service: service}
+    container.AddAdvancedAspect(aspect)
+
+    _, err := container.Invoke(service, "Run")
+    require.NoError(t, err)
+
+    // Before saw the method not yet called, After saw it called with its result
+    assert.Equal(t, []string{"before calls=0", "after calls=1 returned=[done]"}, aspect.events)
+}
+
+type cachingAspect struct {
+    cache map[interface{}]interface{}
+}
+
+func (a *cachingAspect) Kind() aop.AspectKind { return aop.AfterReturning }
+func (a *cachingAspect) PointCut() string     { return "*.GetUser" }
+
+func (a *cachingAspect) Advice(jp *aop.JoinPoint) error {
+    a.cache[jp.Args[0]] = jp.ReturnVals[0]
+    return nil
+}
+
+func TestContainer_InvokeAfterReturningReceivesResults(t *testing.T) {
+    container := NewContainer()
+    aspect := &cachingAspect{cache: make(map[interface{}]interface{})}
+    container.AddAspect(aspect)
+
+    userService := services.NewUserService()
+    for _, id := range []int{1, 42} {
+        _, err := container.Invoke(userService, "GetUser", id)
+        require.NoError(t, err)
+    }
+
+    assert.Equal(t, map[interface{}]interface{}{1: "USER-1", 42: "USER-42"}, aspect.cache)
+}
+
+type securedService struct {
+    calls int
+}
+
+func (s *securedService) DeleteAccount(id int) (bool, error) {
+    s.calls++
+    return true, nil
+}
+
+type denyingAspect struct {
+    useSentinel bool
+}
+
+func (a *denyingAspect) Kind() aop.AspectKind { return aop.Before }
+func (a *denyingAspect) PointCut() string     { return "securedService.*" }
+
+func (a *denyingAspect) Advice(jp *aop.JoinPoint) error {
+    if a.useSentinel {
+        return aop.ErrAbort
+    }
+    jp.Abort = true
+    return nil
+}
+
+func TestContainer_InvokeAbort(t *testing.T) {
+    for _, useSentinel := range []bool{false, true} {
+        t.Run(fmt.Sprintf("sentinel=%v", useSentinel), func(t *testing.T) {
+            container := NewContainer()
+            container.AddAspect(&denyingAspect{useSentinel: useSentinel})
+            laterBefore := &recordingAspect{kind: aop.Before, pointcut: "securedService.*"}
+            after := &recordingAspect{kind: aop.After, pointcut: "securedService.*"}
+            afterReturning := &recordingAspect{kind: aop.AfterReturning, pointcut: "securedService.*"}
+            container.AddAspect(laterBefore)
+            container.AddAspect(after)
+            container.AddAspect(afterReturning)
+
+            service := &securedService{}
+            results, err := container.Invoke(service, "DeleteAccount", 7)
+            require.NoError(t, err, "aborting is not an error")
+
+            assert.Zero(t, service.calls, "the method body never ran")
+            assert.Equal(t, []interface{}{false, nil}, results)
+            assert.Zero(t, laterBefore.calls)
+            assert.Equal(t, 1, after.calls)
+            assert.Zero(t, afterReturning.calls)
+        })
+    }
+}
+
+type nestingAspect struct {
+    name  string
+    order int
+    log   *[]string
+}
+
+func (a *nestingAspect) Kind() aop.AspectKind           { return aop.Around }
+func (a *nestingAspect) PointCut() string               { return "nestedService.*" }
+func (a *nestingAspect) Order() int                     { return a.order }
+func (a *nestingAspect) Advice(jp *aop.JoinPoint) error { return nil }
+
+func (a *nestingAspect) Wrap(jp *aop.JoinPoint, proceed func() error) error {
+    *a.log = append(*a.log, a.name+" before")
+    err := proceed()
+    *a.log = append(*a.log, fmt.Sprintf("%s after %v", a.name, jp.ReturnVals))
+    return err
+}
+
+type nestedService struct {
+    log *[]string
+}
+
+func (s *nestedService) Work() string {
+    *s.log = append(*s.log, "method")
+    return "result"
+}
+
+type skippingAspect struct{}
+
+func (a *skippingAspect) Kind() aop.AspectKind           { return aop.Around }
+func (a *skippingAspect) PointCut() string               { return "nestedService.*" }
+func (a *skippingAspect) Advice(jp *aop.JoinPoint) error { return nil }
+
+// Wrap answers from a cache without proceeding to the method
+func (a *skippingAspect) Wrap(jp *aop.JoinPoint, proceed func() error) error {
+    jp.ReturnVals = []interface{}{"cached"}
+    return nil
+}
+
+func TestContainer_InvokeNestedAroundAspects(t *testing.T) {
+    container := NewContainer()
+    var log []string
+    // Registered inner first; order decides the nesting
+    container.AddAspect(&nestingAspect{name: "inner", order: 2, log: &log})
+    container.AddAspect(&nestingAspect{name: "outer", order: 1, log: &log})
+
+    results, err := container.Invoke(&nestedService{log: &log}, "Work")
+    require.NoError(t, err)
+    assert.Equal(t, []interface{}{"result"}, results)
+    assert.Equal(t, []string{
+        "outer before",
+        "inner before",
+        "method",
+        "inner after [result]",
+        "outer after [result]",
+    }, log)
+}
+
+func TestContainer_InvokeAroundWithoutProceed(t *testing.T) {
+    container := NewContainer()
+    var log []string
+    container.AddAspect(&skippingAspect{})
+
+    results, err := container.Invoke(&nestedService{log: &log}, "Work")
+    require.NoError(t, err)
+    assert.Equal(t, []interface{}{"cached"}, results)
+    assert.Empty(t, log, "the method was not called")
+}
+
+type retryableError struct {
+    attempts int
+}
+
+func (e *retryableError) Error() string { return fmt.Sprintf("retryable after %d attempts", e.attempts) }
+
+type validationError struct {
+    field string
+}
+
+func (e *validationError) Error() string { return "invalid " + e.field }
+
+type flakyService struct{}
+
+func (s *flakyService) Save(fail string) error {
+    switch fail {
+    case "retryable":
+        return fmt.Errorf("save: %w", &retryableError{attempts: 3})
+    case "validation":
+        return &validationError{field: "email"}
+    default:
+        return nil
+    }
+}
+
+// retryAspect only handles retryable errors
+type retryAspect struct {
+    errors []error
+}
+
+func (a *retryAspect) Kind() aop.AspectKind    { return aop.AfterThrowing }
+func (a *retryAspect) PointCut() string        { return "flakyService.*" }
+func (a *retryAspect) ErrorType() reflect.Type { return reflect.TypeOf(&retryableError{}) }
+
+func (a *retryAspect) Advice(jp *aop.JoinPoint) error {
+    a.errors = append(a.errors, jp.Error)
+    return nil
+}
+
+func TestContainer_InvokeAfterThrowingErrorTypeFilter(t *testing.T) {
+    container := NewContainer()
+    retry := &retryAspect{}
+    catchAll := &recordingAspect{kind: aop.AfterThrowing, pointcut: "flakyService.*"}
+    container.AddAspect(retry)
+    container.AddAspect(catchAll)
+
+    _, err := container.Invoke(&flakyService{}, "Save", "validation")
+    assert.EqualError(t, err, "invalid email")
+    assert.Empty(t, retry.errors, "the filtered aspect skips other error types")
+    assert.Equal(t, 1, catchAll.calls)
+
+    _, err = container.Invoke(&flakyService{}, "Save", "retryable")
+    assert.EqualError(t, err, "save: retryable after 3 attempts")
+    require.Len(t, retry.errors, 1)
+    assert.Equal(t, err, retry.errors[0])
+    assert.Equal(t, 2, catchAll.calls)
+
+    _, err = container.Invoke(&flakyService{}, "Save", "")
+    require.NoError(t, err)
+    assert.Len(t, retry.errors, 1)
+    assert.Equal(t, 2, catchAll.calls)
+}
+
+func TestContainer_ExecuteAspectsErrorTypeFilter(t *testing.T) {
+    container := NewContainer()
+    retry := &retryAspect{}
+    catchAll := &recordingAspect{kind: aop.AfterThrowing, pointcut: "flakyService.*"}
+    container.AddAspect(retry)
+    container.AddAspect(catchAll)
+
+    jp := &aop.JoinPoint{Target: &flakyService{}, Error: &validationError{field: "email"}}
+    require.NoError(t, container.ExecuteAspects(jp))
+    assert.Empty(t, retry.errors, "the filtered aspect skips other error types")
+    assert.Equal(t, 1, catchAll.calls)
+
+    jp.Error = fmt.Errorf("save: %w", &retryableError{attempts: 3})
+    require.NoError(t, container.ExecuteAspects(jp))
+    assert.Equal(t, []error{jp.Error}, retry.errors)
+    assert.Equal(t, 2, catchAll.calls)
+}
+
+func TestContainer_InvokeMetricsAspect(t *testing.T) {
+    container := NewContainer()
+    metrics := aop.NewMetricsAspect("flakyService.*")
+    container.AddAdvancedAspect(metrics)
+
+    for _, fail := range []string{"", "", "retryable", "", "validation"} {
+        _, err := container.Invoke(&flakyService{}, "Save", fail)
+        assert.Equal(t, fail != "", err != nil)
+    }
+
+    assert.Equal(t, map[string]aop.AspectMetrics{
+        "flakyService.Save": {Invocations: 5, Errors: 2},
+    }, metrics.Snapshot())
+}
+
+func TestContainer_ExecuteAspectsMetricsAspect(t *testing.T) {
+    container := NewContainer()
+    metrics := aop.NewMetricsAspect("flakyService.*")
+    container.AddAdvancedAspect(metrics)
+
+    jp, err := aop.NewJoinPoint(&flakyService{}, "Save", "")
+    require.NoError(t, err)
+    require.NoError(t, container.ExecuteAspects(jp))
+
+    // A failed call only runs AfterThrowing, so it is counted once
+    jp.Error = &validationError{field: "email"}
+    require.NoError(t, container.ExecuteAspects(jp))
+
+    assert.Equal(t, map[string]aop.AspectMetrics{
+        "flakyService.Save": {Invocations: 2, Errors: 1},
+    }, metrics.Snapshot())
+}
@@ -1,6 +1,13 @@
 // pkg/container/lifecycle.go
 package container
 
+import (
+    "fmt"
+    "reflect"
+    "sync"
+    "time"
+)
+
 // LifecycleAware defines methods for objects that need initialization and cleanup
 type LifecycleAware interface {
     // PostConstruct is called after dependency injection is complete
@@ -17,15 +24,61 @@ type LifecycleHook struct {
     Name     string                  // Identifier for the hook
     Priority int                     // Execution priority (lower numbers execute first)
     Handler  func(interface{}) error // Function to execute at lifecycle point
+    Scopes   []Scope                 // Scopes of the instances the hook runs for, empty for all
+}
+
+// appliesTo reports whether the hook runs for an instance of scope
+func (h LifecycleHook) appliesTo(scope Scope) bool {
+    if len(h.Scopes) == 0 {
+        return true
+    }
+    for _, allowed := range h.Scopes {
+        if allowed == scope {
+            return true
+        }
+    }
+    return false
+}
+
+// noScope is the scope of instances the container does not manage, such as InjectStruct
+// targets; only hooks without a Scopes filter run for them
+const noScope Scope = -1
+
+// HookPhase names the lifecycle point a hook ran at
+type HookPhase string
+
+const (
+    PostConstructPhase HookPhase = "post-construct"
+    PreDestroyPhase    HookPhase = "pre-destroy"
+)
+
+// HookResult records the outcome of one hook execution
+type HookResult struct {
+    Name     string        // Name of the hook
+    Phase    HookPhase     // Lifecycle point the hook ran at
+    Duration time.Duration // Time spent in the hook's handler
+    Err      error         // Error returned by the handler, nil on success
 }
 
 // LifecycleManager handles the execution of lifecycle hooks
 type LifecycleManager struct {
+    mu sync.Mutex
+
     // Hooks executed after object construction/initialization
     postConstructHooks []LifecycleHook
 
     // Hooks executed before object destruction
     preDestroyHooks []LifecycleHook
+
+    // Results of the most recent hook run
+    lastRun []HookResult
+
+    // Conventional methods called on instances that are not LifecycleAware, empty for none
+    initMethodName    string
+    destroyMethodName string
+
+    // Set while the owning container is frozen; hooks and method names can then not change
+    frozen bool
 }
 
 // NewLifecycleManager creates a new lifecycle manager instance
@@ -37,12 +90,102 @@ func NewLifecycleManager() *LifecycleManager {
     }
 }
 
-// AddPostConstructHook registers a hook to run after object construction
+// setFrozen follows Freeze and Reset of the owning container
+func (lm *LifecycleManager) setFrozen(frozen bool) {
+    lm.mu.Lock()
+    defer lm.mu.Unlock()
+    lm.frozen = frozen
+}
+
+// checkMutable panics when the owning container is frozen. The caller must hold the lock.
+func (lm *LifecycleManager) checkMutable(operation string) {
+    if lm.frozen {
+        panic(fmt.Errorf("cannot %s: container is frozen", operation))
+    }
+}
+
+// AddPostConstructHook registers a hook to run after object construction.
+// It panics while the owning container is frozen.
 func (lm *LifecycleManager) AddPostConstructHook(hook LifecycleHook) {
+    lm.mu.Lock()
+    defer lm.mu.Unlock()
+    lm.checkMutable("add post-construct hook " + hook.Name)
     lm.postConstructHooks = append(lm.postConstructHooks, hook)
 }
 
-// AddPreDestroyHook registers a hook to run before object destruction
+// AddPreDestroyHook registers a hook to run before object destruction.
+// It panics while the owning container is frozen.
 func (lm *LifecycleManager) AddPreDestroyHook(hook LifecycleHook) {
+    lm.mu.Lock()
+    defer lm.mu.Unlock()
+    lm.checkMutable("add pre-destroy hook " + hook.Name)
     lm.preDestroyHooks = append(lm.preDestroyHooks, hook)
-}
\ No newline at end of file
+}
+// LastRunReport returns the results of the most recent hook run, i.e. the hooks of one phase
+// executed for one instance. Hooks stop at the first failure, so hooks after it are absent.
+// A run without hooks leaves the previous report in place.
+func (lm *LifecycleManager) LastRunReport() []HookResult {
+    lm.mu.Lock()
+    defer lm.mu.Unlock()
+    return append([]HookResult(nil), lm.lastRun...)
+}
+
+// runHooks executes the hooks of phase that apply to scope against instance, records them as
+// the last run and returns the error of the first failing hook
+func (lm *LifecycleManager) runHooks(phase HookPhase, scope Scope, instance interface{}) error {
+    lm.mu.Lock()
+    registered := lm.postConstructHooks
+    if phase == PreDestroyPhase {
+        registered = lm.preDestroyHooks
+    }
+    hooks := make([]LifecycleHook, 0, len(registered))
+    for _, hook := range registered {
+        if hook.appliesTo(scope) {
+            hooks = append(hooks, hook)
+        }
+    }
+    lm.mu.Unlock()
+    if len(hooks) == 0 {
+        return nil
+    }
+
+    // Handlers run without the lock so they may add hooks or read the report
+    report := make([]HookResult, 0, len(hooks))
+    for _, hook := range hooks {
+        start := time.Now()
+        err := hook.Handler(instance)
+        report = append(report, HookResult{
+            Name:     hook.Name,
+            Phase:    phase,
+            Duration: time.Since(start),
+            Err:      err,
+        })
+        if err != nil {
+            break
+        }
+    }
+
+    lm.mu.Lock()
+    lm.lastRun = report
+    lm.mu.Unlock()
+
+    return report[len(report)-1].Err
+}
+
+var lifecycleAwareType = reflect.TypeOf((*LifecycleAware)(nil)).Elem()
+
+// LifecycleAwareServices returns the qualifiers of the services in this container that
+// implement LifecycleAware, in registration order, e.g. for a coordinator driving shutdown.
+// A service counts when its built instance or its registered type implements the interface;
+// prototypes registered with a bare factory have no known type and are not listed.
+func (c *Container) LifecycleAwareServices() []string {
+    result := c.findQualifiers(func(service *ScopedService) bool {
+        if service.Instance != nil {
+            _, ok := service.Instance.(LifecycleAware)
+            return ok
+        }
+        return service.Type != nil && service.Type.Implements(lifecycleAwareType)
+    })
+    c.log.Debugw("Found lifecycle-aware services", "qualifiers", result)
+    return result
+}
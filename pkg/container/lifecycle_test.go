@@ -0,0 +1,97 @@
+package container
+
+import (
+    "errors"
+    "testing"
+    "time"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+func TestLifecycleManager_LastRunReport(t *testing.T) {
+    container := NewContainer()
+    lm := container.GetLifecycleManager()
+    assert.Empty(t, lm.LastRunReport())
+
+    errMigration := errors.New("migration pending")
+    ran := make([]string, 0)
+    hook := func(name string, delay time.Duration, err error) LifecycleHook {
+        return LifecycleHook{Name: name, Handler: func(interface{}) error {
+            ran = append(ran, name)
+            time.Sleep(delay)
+            return err
+        }}
+    }
+    lm.AddPostConstructHook(hook("metrics", 0, nil))
+    lm.AddPostConstructHook(hook("warmup", 10*time.Millisecond, nil))
+    lm.AddPostConstructHook(hook("migrate", 0, errMigration))
+    lm.AddPostConstructHook(hook("announce", 0, nil))
+    lm.AddPreDestroyHook(hook("flush", 0, nil))
+
+    err := container.Register("service", &testServiceImpl{}, Singleton)
+    require.ErrorIs(t, err, errMigration)
+
+    report := lm.LastRunReport()
+    require.Len(t, report, 3, "hooks after the failing one do not run")
+    assert.Equal(t, []string{"metrics", "warmup", "migrate"}, ran)
+    for i, name := range ran {
+        assert.Equal(t, name, report[i].Name)
+        assert.Equal(t, PostConstructPhase, report[i].Phase)
+    }
+    assert.NoError(t, report[0].Err)
+    assert.NoError(t, report[1].Err)
+    assert.GreaterOrEqual(t, report[1].Duration, 10*time.Millisecond)
+    assert.ErrorIs(t, report[2].Err, errMigration)
+
+    // A pre-destroy run replaces the report
+    require.NoError(t, container.preDestroy("service", Singleton, &testServiceImpl{}))
+    report = lm.LastRunReport()
+    require.Len(t, report, 1)
+    assert.Equal(t, HookResult{Name: "flush", Phase: PreDestroyPhase, Duration: report[0].Duration}, report[0])
+}
+
+func TestLifecycleHook_Scopes(t *testing.T) {
+    container := NewContainer()
+    lm := container.GetLifecycleManager()
+
+    ran := make(map[string]int)
+    hook := func(name string, scopes ...Scope) LifecycleHook {
+        return LifecycleHook{Name: name, Scopes: scopes, Handler: func(interface{}) error {
+            ran[name]++
+            return nil
+        }}
+    }
+    lm.AddPostConstructHook(hook("warmCache", Singleton))
+    lm.AddPostConstructHook(hook("resetState", Prototype))
+    lm.AddPostConstructHook(hook("everyInstance"))
+
+    require.NoError(t, container.Register("singleton", &testServiceImpl{}, Singleton))
+    assert.Equal(t, map[string]int{"warmCache": 1, "everyInstance": 1}, ran)
+
+    require.NoError(t, container.Register("prototype", &testServiceImpl{}, Prototype))
+    for i := 0; i < 2; i++ {
+        _, err := container.Resolve("prototype")
+        require.NoError(t, err)
+    }
+    assert.Equal(t, map[string]int{"warmCache": 1, "resetState": 2, "everyInstance": 3}, ran,
+        "the singleton-only hook does not run for prototypes")
+
+    // Struct injection targets are not scoped, so only unfiltered hooks run for them
+    require.NoError(t, container.InjectStruct(&testServiceImpl{}))
+    assert.Equal(t, map[string]int{"warmCache": 1, "resetState": 2, "everyInstance": 4}, ran)
+}
+
+func TestContainer_LifecycleAwareServices(t *testing.T) {
+    container := NewContainer()
+    require.NoError(t, container.Register("plain", &TestStruct{}, Singleton))
+    require.NoError(t, container.Register("database", &testServiceImpl{}, Singleton))
+    require.NoError(t, container.Register("session", &testServiceImpl{}, WeakSingleton))
+    require.NoError(t, container.RegisterConstructor("cache", func() (*testServiceImpl, error) {
+        return &testServiceImpl{}, nil
+    }, Prototype))
+    require.NoError(t, container.RegisterPrototypeFactory("untyped", func() interface{} { return &testServiceImpl{} }))
+    require.NoError(t, container.Register("port", 8080, Singleton))
+
+    assert.Equal(t, []string{"database", "session", "cache"}, container.LifecycleAwareServices())
+}
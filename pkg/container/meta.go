@@ -0,0 +1,81 @@
+// pkg/container/meta.go
+package container
+
+import (
+    "sort"
+)
+
+// RegisterWithMeta registers a service and attaches metadata labels to it,
+// e.g. {"layer": "repository"}, so related services can be enumerated with FindByMeta.
+// The map is copied, so later changes by the caller do not affect the registration.
+func (c *Container) RegisterWithMeta(qualifier string, service interface{}, scope Scope, meta map[string]string) error {
+    return c.register(qualifier, service, scope, func(s *ScopedService) {
+        s.Metadata = make(map[string]string, len(meta))
+        for key, value := range meta {
+            s.Metadata[key] = value
+        }
+    })
+}
+
+// FindByMeta returns the qualifiers of the services in this container whose metadata
+// has key set to value, in registration order
+func (c *Container) FindByMeta(key, value string) []string {
+    result := c.findQualifiers(func(service *ScopedService) bool {
+        actual, ok := service.Metadata[key]
+        return ok && actual == value
+    })
+    c.log.Debugw("Found services by metadata", "key", key, "value", value, "qualifiers", result)
+    return result
+}
+
+// FindByGroup returns the qualifiers of the services in this container that belong to group,
+// in registration order
+func (c *Container) FindByGroup(group string) []string {
+    result := c.findQualifiers(func(service *ScopedService) bool {
+        for _, candidate := range service.Groups {
+            if candidate == group {
+                return true
+            }
+        }
+        return false
+    })
+    c.log.Debugw("Found services by group", "group", group, "qualifiers", result)
+    return result
+}
+
+// findQualifiers returns the qualifiers of the services matching match, in registration order
+func (c *Container) findQualifiers(match func(*ScopedService) bool) []string {
+    c.mu.RLock()
+    defer c.mu.RUnlock()
+
+    matches := make([]*ScopedService, 0)
+    qualifiers := make(map[*ScopedService]string)
+    for qualifier, service := range c.services {
+        if match(service) {
+            matches = append(matches, service)
+            qualifiers[service] = qualifier
+        }
+    }
+    sort.Slice(matches, func(i, j int) bool { return matches[i].order < matches[j].order })
+
+    result := make([]string, len(matches))
+    for i, service := range matches {
+        result[i] = qualifiers[service]
+    }
+    return result
+}
+
+// InstanceID returns the id of the service registered under qualifier in this container.
+// Ids are handed out in registration order, are unique within the container and stay stable
+// for the lifetime of the registration, so logs can tell apart services of the same type;
+// registration and resolution logs carry them as "instanceID".
+func (c *Container) InstanceID(qualifier string) (uint64, bool) {
+    c.mu.RLock()
+    defer c.mu.RUnlock()
+
+    service, exists := c.services[qualifier]
+    if !exists {
+        return 0, false
+    }
+    return service.order, true
+}
@@ -0,0 +1,68 @@
+package container
+
+import (
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+func TestContainer_FindByMeta(t *testing.T) {
+    container := NewContainer()
+    meta := map[string]string{"layer": "repository", "store": "postgres"}
+    require.NoError(t, container.RegisterWithMeta("userRepo", &TestStruct{}, Singleton, meta))
+    require.NoError(t, container.RegisterWithMeta("orderRepo", &TestStruct{}, Singleton,
+        map[string]string{"layer": "repository", "store": "redis"}))
+    require.NoError(t, container.RegisterWithMeta("orderHandler", &TestStruct{}, Singleton,
+        map[string]string{"layer": "web"}))
+    require.NoError(t, container.Register("plain", &TestStruct{}, Singleton))
+
+    // Mutating the caller's map after registration has no effect
+    meta["layer"] = "changed"
+
+    tests := []struct {
+        name     string
+        key      string
+        value    string
+        expected []string
+    }{
+        {name: "shared tag", key: "layer", value: "repository", expected: []string{"userRepo", "orderRepo"}},
+        {name: "single match", key: "store", value: "redis", expected: []string{"orderRepo"}},
+        {name: "no match", key: "layer", value: "changed", expected: []string{}},
+        {name: "unknown key", key: "team", value: "core", expected: []string{}},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            assert.Equal(t, tt.expected, container.FindByMeta(tt.key, tt.value))
+        })
+    }
+}
+
+func TestContainer_InstanceID(t *testing.T) {
+    container := NewContainer()
+    primary := &TestStruct{}
+    require.NoError(t, container.Register("primaryRepo", primary, Singleton))
+    require.NoError(t, container.Register("replicaRepo", &TestStruct{}, WeakSingleton))
+
+    primaryID, ok := container.InstanceID("primaryRepo")
+    require.True(t, ok)
+    replicaID, ok := container.InstanceID("replicaRepo")
+    require.True(t, ok)
+    assert.NotEqual(t, primaryID, replicaID)
+    assert.Less(t, primaryID, replicaID, "ids are handed out in registration order")
+
+    // Resolving, including building a lazy instance, keeps the registered id
+    resolved, err := container.Resolve("primaryRepo")
+    require.NoError(t, err)
+    assert.Same(t, primary, resolved)
+    _, err = container.Resolve("replicaRepo")
+    require.NoError(t, err)
+    id, _ := container.InstanceID("primaryRepo")
+    assert.Equal(t, primaryID, id)
+    id, _ = container.InstanceID("replicaRepo")
+    assert.Equal(t, replicaID, id)
+
+    _, ok = container.InstanceID("unknown")
+    assert.False(t, ok)
+}
@@ -0,0 +1,98 @@
+// pkg/container/metrics.go
+package container
+
+import (
+    "fmt"
+)
+
+// Counter is a monotonically increasing metric, satisfied by prometheus.Counter
+type Counter interface {
+    Inc()
+}
+
+// MetricsRegisterer creates the container's metrics in a monitoring backend. It keeps the
+// container free of a Prometheus dependency; a Prometheus adapter is a few lines:
+// NewCounter registers prometheus.NewCounter and NewGaugeFunc registers prometheus.NewGaugeFunc
+// with a prometheus.Registerer.
+type MetricsRegisterer interface {
+    NewCounter(name, help string) (Counter, error)
+    NewGaugeFunc(name, help string, fn func() float64) error
+}
+
+// Metric names published by RegisterMetrics
+const (
+    MetricRegistrations      = "di_registrations_total"
+    MetricPrototypeCreations = "di_prototype_creations_total"
+    MetricResolutionErrors   = "di_resolution_errors_total"
+    MetricActiveSingletons   = "di_active_singletons"
+)
+
+// containerMetrics holds the counters updated by the container once metrics are registered
+type containerMetrics struct {
+    registrations      Counter
+    prototypeCreations Counter
+    resolutionErrors   Counter
+}
+
+// RegisterMetrics publishes the container's metrics through reg: counters for registrations,
+// prototype creations and resolution errors, and a gauge of built singleton instances.
+// Counters start at zero when registered and only count what happens afterwards.
+// A frozen container rejects it.
+func (c *Container) RegisterMetrics(reg MetricsRegisterer) error {
+    c.mu.Lock()
+    err := c.checkMutable("register", "metrics")
+    c.mu.Unlock()
+    if err != nil {
+        return err
+    }
+
+    metrics := &containerMetrics{}
+    counters := []struct {
+        target *Counter
+        name   string
+        help   string
+    }{
+        {&metrics.registrations, MetricRegistrations, "Number of services registered in the container."},
+        {&metrics.prototypeCreations, MetricPrototypeCreations, "Number of prototype instances created."},
+        {&metrics.resolutionErrors, MetricResolutionErrors, "Number of failed service resolutions."},
+    }
+    for _, counter := range counters {
+        created, err := reg.NewCounter(counter.name, counter.help)
+        if err != nil {
+            c.log.Errorw("Failed to register metric", "metric", counter.name, "error", err)
+            return fmt.Errorf("failed to register metric %s: %w", counter.name, err)
+        }
+        *counter.target = created
+    }
+
+    if err := reg.NewGaugeFunc(MetricActiveSingletons, "Number of singleton instances currently built.",
+        c.activeSingletons); err != nil {
+        c.log.Errorw("Failed to register metric", "metric", MetricActiveSingletons, "error", err)
+        return fmt.Errorf("failed to register metric %s: %w", MetricActiveSingletons, err)
+    }
+
+    c.metrics.Store(metrics)
+    c.log.Infow("Registered container metrics", "container", c.id)
+    return nil
+}
+
+// activeSingletons counts the cached instances currently held by the container
+func (c *Container) activeSingletons() float64 {
+    c.mu.RLock()
+    defer c.mu.RUnlock()
+
+    count := 0
+    for _, service := range c.services {
+        if service.Scope.caches() && service.Instance != nil {
+            count++
+        }
+    }
+    return float64(count)
+}
+
+// countMetric increments the counter picked by pick when metrics are registered
+func (c *Container) countMetric(pick func(*containerMetrics) Counter) {
+    if metrics := c.metrics.Load(); metrics != nil {
+        pick(metrics).Inc()
+    }
+}
@@ -0,0 +1,90 @@
+package container
+
+import (
+    "errors"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+type fakeCounter struct {
+    value int
+}
+
+func (f *fakeCounter) Inc() { f.value++ }
+
+type fakeRegisterer struct {
+    counters map[string]*fakeCounter
+    gauges   map[string]func() float64
+    fail     string
+}
+
+func newFakeRegisterer() *fakeRegisterer {
+    return &fakeRegisterer{
+        counters: make(map[string]*fakeCounter),
+        gauges:   make(map[string]func() float64),
+    }
+}
+
+func (f *fakeRegisterer) NewCounter(name, help string) (Counter, error) {
+    if name == f.fail {
+        return nil, errors.New("duplicate metrics collector registration attempted")
+    }
+    counter := &fakeCounter{}
+    f.counters[name] = counter
+    return counter, nil
+}
+
+func (f *fakeRegisterer) NewGaugeFunc(name, help string, fn func() float64) error {
+    f.gauges[name] = fn
+    return nil
+}
+
+func TestContainer_RegisterMetrics(t *testing.T) {
+    container := NewContainer()
+    reg := newFakeRegisterer()
+    require.NoError(t, container.RegisterMetrics(reg))
+
+    assert.ElementsMatch(t,
+        []string{MetricRegistrations, MetricPrototypeCreations, MetricResolutionErrors},
+        keys(reg.counters))
+    require.Contains(t, reg.gauges, MetricActiveSingletons)
+
+    require.NoError(t, container.Register("singleton", &testServiceImpl{}, Singleton))
+    require.NoError(t, container.Register("weak", &testServiceImpl{}, WeakSingleton))
+    require.NoError(t, container.RegisterPrototypeFactory("prototype", func() interface{} { return &TestStruct{} }))
+    for i := 0; i < 3; i++ {
+        _, err := container.Resolve("prototype")
+        require.NoError(t, err)
+    }
+    _, err := container.Resolve("missing")
+    require.Error(t, err)
+
+    assert.Equal(t, 3, reg.counters[MetricRegistrations].value)
+    assert.Equal(t, 3, reg.counters[MetricPrototypeCreations].value)
+    assert.Equal(t, 1, reg.counters[MetricResolutionErrors].value)
+    // The weak singleton is not built until its first resolve
+    assert.Equal(t, 1.0, reg.gauges[MetricActiveSingletons]())
+    _, err = container.Resolve("weak")
+    require.NoError(t, err)
+    assert.Equal(t, 2.0, reg.gauges[MetricActiveSingletons]())
+}
+
+func TestContainer_RegisterMetricsError(t *testing.T) {
+    container := NewContainer()
+    reg := newFakeRegisterer()
+    reg.fail = MetricResolutionErrors
+
+    err := container.RegisterMetrics(reg)
+    assert.ErrorContains(t, err, "failed to register metric di_resolution_errors_total")
+    assert.Nil(t, container.metrics.Load())
+}
+
+func keys(m map[string]*fakeCounter) []string {
+    result := make([]string, 0, len(m))
+    for key := range m {
+        result = append(result, key)
+    }
+    return result
+}
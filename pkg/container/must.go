@@ -0,0 +1,36 @@
+// pkg/container/must.go
+package container
+
+import (
+    "fmt"
+    "reflect"
+)
+
+// MustResolve resolves qualifier like Resolve but panics when resolution fails.
+// It is meant for startup code that cannot run without the service:
+//
+//     users := c.MustResolve("userService").(services.UserService)
+func (c *Container) MustResolve(qualifier string) interface{} {
+    instance, err := c.Resolve(qualifier)
+    if err != nil {
+        c.log.Errorw("Required service could not be resolved", "qualifier", qualifier, "error", err)
+        panic(fmt.Sprintf("container: must resolve %s: %v", qualifier, err))
+    }
+    return instance
+}
+
+// MustResolveAs resolves qualifier and returns it as a T, panicking when resolution fails or
+// the service is not a T
+func MustResolveAs[T any](c *Container, qualifier string) T {
+    instance := c.MustResolve(qualifier)
+    typed, ok := instance.(T)
+    if !ok {
+        expected := reflect.TypeOf((*T)(nil)).Elem()
+        c.log.Errorw("Resolved service has unexpected type",
+            "qualifier", qualifier,
+            "expectedType", expected,
+            "actualType", reflect.TypeOf(instance))
+        panic(fmt.Sprintf("container: must resolve %s: service of type %T is not a %v", qualifier, instance, expected))
+    }
+    return typed
+}
@@ -0,0 +1,34 @@
+package container
+
+import (
+    "fmt"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+func TestContainer_MustResolve(t *testing.T) {
+    container := NewContainer()
+    service := &testServiceImpl{}
+    require.NoError(t, container.Register("testService", service, Singleton))
+
+    assert.Same(t, service, container.MustResolve("testService"))
+    assert.PanicsWithValue(t, "container: must resolve missingService: no service found for qualifier: missingService", func() {
+        container.MustResolve("missingService")
+    })
+}
+
+func TestMustResolveAs(t *testing.T) {
+    container := NewContainer()
+    service := &testServiceImpl{}
+    require.NoError(t, container.Register("testService", service, Singleton))
+
+    assert.Same(t, service, MustResolveAs[TestService](container, "testService"))
+    assert.PanicsWithValue(t, "container: must resolve testService: service of type *container.testServiceImpl is not a fmt.Stringer", func() {
+        MustResolveAs[fmt.Stringer](container, "testService")
+    })
+    assert.PanicsWithValue(t, "container: must resolve missingService: no service found for qualifier: missingService", func() {
+        MustResolveAs[TestService](container, "missingService")
+    })
+}
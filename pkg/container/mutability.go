@@ -0,0 +1,62 @@
+// pkg/container/mutability.go
+package container
+
+import (
+    "reflect"
+)
+
+// ThreadSafe marks a service type as safe for concurrent use, e.g. because it guards its
+// mutable fields with a mutex. WarnMutableSingletons does not report such types.
+type ThreadSafe interface {
+    ThreadSafe()
+}
+
+var threadSafeType = reflect.TypeOf((*ThreadSafe)(nil)).Elem()
+
+// WarnMutableSingletons enables a best-effort check at registration of singletons and weak
+// singletons: exported map, slice and pointer fields of the service's struct type are logged as
+// a warning, since every caller shares the instance. Types implementing ThreadSafe are skipped.
+func (c *Container) WarnMutableSingletons() {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    c.warnMutable = true
+    c.log.Infow("Enabled mutable singleton warnings")
+}
+
+// warnIfMutable logs the exported mutable fields of a shared service type.
+// The caller must hold the lock.
+func (c *Container) warnIfMutable(qualifier string, t reflect.Type) {
+    if !c.warnMutable || t == nil || t.Implements(threadSafeType) {
+        return
+    }
+    if fields := mutableFields(t); len(fields) > 0 {
+        c.log.Warnw("Singleton has exported mutable fields and may not be safe for concurrent use",
+            "qualifier", qualifier,
+            "type", t,
+            "fields", fields)
+    }
+}
+
+// mutableFields returns the names of the exported map, slice and pointer fields of a struct
+// (or pointer to struct) type
+func mutableFields(t reflect.Type) []string {
+    if t.Kind() == reflect.Ptr {
+        t = t.Elem()
+    }
+    if t.Kind() != reflect.Struct {
+        return nil
+    }
+
+    fields := make([]string, 0)
+    for i := 0; i < t.NumField(); i++ {
+        field := t.Field(i)
+        if field.PkgPath != "" {
+            continue
+        }
+        switch field.Type.Kind() {
+        case reflect.Map, reflect.Slice, reflect.Ptr:
+            fields = append(fields, field.Name)
+        }
+    }
+    return fields
+}
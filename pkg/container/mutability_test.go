@@ -0,0 +1,49 @@
+package container
+
+import (
+    "sync"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+    "go.uber.org/zap"
+    "go.uber.org/zap/zapcore"
+    "go.uber.org/zap/zaptest/observer"
+)
+
+type sessionStore struct {
+    Sessions map[string]string
+    Tags     []string
+    Owner    *TestStruct
+    Name     string
+    cache    map[string]string
+}
+
+type guardedStore struct {
+    mu       sync.Mutex
+    Sessions map[string]string
+}
+
+func (s *guardedStore) ThreadSafe() {}
+
+func TestContainer_WarnMutableSingletons(t *testing.T) {
+    container := NewContainer()
+    core, logs := observer.New(zapcore.WarnLevel)
+    container.log = zap.New(core).Sugar()
+
+    // Disabled by default
+    require.NoError(t, container.Register("quiet", &sessionStore{}, Singleton))
+    assert.Zero(t, logs.FilterMessageSnippet("mutable fields").Len())
+
+    container.WarnMutableSingletons()
+    require.NoError(t, container.Register("sessions", &sessionStore{}, Singleton))
+    require.NoError(t, container.Register("guarded", &guardedStore{}, Singleton))
+    require.NoError(t, container.RegisterConstructor("lazySessions", func() *sessionStore { return &sessionStore{} }, WeakSingleton))
+    require.NoError(t, container.RegisterConstructor("prototypeSessions", func() *sessionStore { return &sessionStore{} }, Prototype))
+
+    warnings := logs.FilterMessageSnippet("mutable fields").All()
+    require.Len(t, warnings, 2, "the ThreadSafe type and the prototype are not reported")
+    assert.Equal(t, "sessions", warnings[0].ContextMap()["qualifier"])
+    assert.Equal(t, []interface{}{"Sessions", "Tags", "Owner"}, warnings[0].ContextMap()["fields"])
+    assert.Equal(t, "lazySessions", warnings[1].ContextMap()["qualifier"])
+}
@@ -0,0 +1,51 @@
+// pkg/container/override.go
+package container
+
+import (
+    "fmt"
+    "reflect"
+    "sync"
+)
+
+// PushOverride temporarily binds qualifier to svc as a singleton, typically a mock in tests.
+// The returned restore function puts back the previous binding (or removes the override when
+// there was none) and is meant to be deferred. Overrides nest: restore them in reverse order.
+// It panics on a frozen container.
+func (c *Container) PushOverride(qualifier string, svc interface{}) (restore func()) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+
+    if err := c.checkMutable("override", qualifier); err != nil {
+        panic(err)
+    }
+
+    previous, existed := c.services[qualifier]
+    poolSize := c.discardPool(qualifier)
+    c.store(qualifier, &ScopedService{
+        Instance:     svc,
+        Scope:        Singleton,
+        Type:         reflect.TypeOf(svc),
+        Factory:      func() interface{} { return svc },
+        Dependencies: make([]string, 0),
+    })
+    c.log.Infow("Pushed service override",
+        "qualifier", qualifier,
+        "type", fmt.Sprintf("%T", svc),
+        "replaced", existed)
+
+    var once sync.Once
+    return func() {
+        once.Do(func() {
+            c.mu.Lock()
+            defer c.mu.Unlock()
+
+            if existed {
+                c.services[qualifier] = previous
+                c.enablePoolAsync(qualifier, previous, poolSize)
+            } else {
+                delete(c.services, qualifier)
+            }
+            c.log.Infow("Restored service override", "qualifier", qualifier)
+        })
+    }
+}
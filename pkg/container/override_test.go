@@ -0,0 +1,56 @@
+package container
+
+import (
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+func TestContainer_PushOverride(t *testing.T) {
+    container := NewContainer()
+    original := &testServiceImpl{name: "original"}
+    require.NoError(t, container.Register("testService", original, Singleton))
+
+    mock := &testServiceImpl{name: "mock"}
+    restore := container.PushOverride("testService", mock)
+
+    resolved, err := container.Resolve("testService")
+    require.NoError(t, err)
+    assert.Same(t, mock, resolved)
+
+    restore()
+    resolved, err = container.Resolve("testService")
+    require.NoError(t, err)
+    assert.Same(t, original, resolved)
+
+    // Restoring twice is a no-op
+    restore()
+    resolved, err = container.Resolve("testService")
+    require.NoError(t, err)
+    assert.Same(t, original, resolved)
+}
+
+func TestContainer_PushOverrideNested(t *testing.T) {
+    container := NewContainer()
+
+    first := &testServiceImpl{name: "first"}
+    second := &testServiceImpl{name: "second"}
+
+    restoreFirst := container.PushOverride("newService", first)
+    restoreSecond := container.PushOverride("newService", second)
+
+    resolved, err := container.Resolve("newService")
+    require.NoError(t, err)
+    assert.Same(t, second, resolved)
+
+    restoreSecond()
+    resolved, err = container.Resolve("newService")
+    require.NoError(t, err)
+    assert.Same(t, first, resolved)
+
+    // Overriding an unregistered qualifier removes it again on restore
+    restoreFirst()
+    _, err = container.Resolve("newService")
+    assert.Error(t, err)
+}
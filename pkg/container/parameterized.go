@@ -0,0 +1,85 @@
+// pkg/container/parameterized.go
+package container
+
+import (
+    "fmt"
+)
+
+// RegisterParameterizedPrototype registers a prototype whose factory receives per-creation
+// arguments, e.g. the tenant a connection is for. ResolveWith passes its arguments to the
+// factory; a plain Resolve calls the factory without arguments.
+func (c *Container) RegisterParameterizedPrototype(qualifier string, factory func(args ...interface{}) (interface{}, error)) error {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+
+    c.log.Infow("Registering parameterized prototype", "qualifier", qualifier)
+
+    if err := c.checkMutable("register", qualifier); err != nil {
+        return err
+    }
+    if err := c.validateQualifier(qualifier); err != nil {
+        return err
+    }
+    if factory == nil {
+        c.log.Errorw("Cannot register nil factory", "qualifier", qualifier)
+        return fmt.Errorf("cannot register nil factory for qualifier: %s", qualifier)
+    }
+    if _, exists := c.services[qualifier]; exists {
+        c.log.Errorw("Service already registered", "qualifier", qualifier)
+        return fmt.Errorf("service already registered for qualifier: %s", qualifier)
+    }
+
+    construct := func() (interface{}, error) { return factory() }
+    c.store(qualifier, &ScopedService{
+        Scope: Prototype,
+        Factory: func() interface{} {
+            instance, err := construct()
+            if err != nil {
+                c.log.Errorw("Parameterized factory failed", "qualifier", qualifier, "error", err)
+                return nil
+            }
+            return instance
+        },
+        Dependencies:  make([]string, 0),
+        construct:     construct,
+        parameterized: factory,
+    })
+    return nil
+}
+
+// ResolveWith builds a new instance of a parameterized prototype, passing args to its factory.
+// The service is looked up in this container and then its ancestors; the new instance runs
+// through post-construct and the post-processors like any other prototype.
+func (c *Container) ResolveWith(qualifier string, args ...interface{}) (interface{}, error) {
+    for current := c; current != nil; {
+        current.mu.RLock()
+        service, exists := current.services[qualifier]
+        parent := current.parent
+        processors := current.postProcessors
+        current.mu.RUnlock()
+
+        if !exists {
+            current = parent
+            continue
+        }
+        if service.parameterized == nil {
+            c.log.Errorw("Service is not a parameterized prototype", "qualifier", qualifier, "scope", service.Scope)
+            return nil, fmt.Errorf("service %s is not a parameterized prototype", qualifier)
+        }
+
+        c.log.Debugw("Resolving parameterized prototype",
+            "qualifier", qualifier,
+            "container", current.id,
+            "args", len(args))
+        instance, err := current.build(qualifier, service.Scope, func() (interface{}, error) {
+            return service.parameterized(args...)
+        }, processors, current.decoratorsOf(service))
+        if err == nil {
+            current.countMetric(func(m *containerMetrics) Counter { return m.prototypeCreations })
+        }
+        return instance, err
+    }
+
+    c.log.Errorw("Service not found", "qualifier", qualifier, "container", c.id)
+    return nil, fmt.Errorf("no service found for qualifier: %s", qualifier)
+}
@@ -0,0 +1,51 @@
+package container
+
+import (
+    "errors"
+    "fmt"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+type tenantConnection struct {
+    tenant string
+}
+
+func TestContainer_ResolveWith(t *testing.T) {
+    parent := NewContainer()
+    require.NoError(t, parent.RegisterParameterizedPrototype("connection", func(args ...interface{}) (interface{}, error) {
+        if len(args) != 1 {
+            return nil, fmt.Errorf("expected a tenant, got %d arguments", len(args))
+        }
+        tenant, ok := args[0].(string)
+        if !ok {
+            return nil, errors.New("tenant must be a string")
+        }
+        return &tenantConnection{tenant: tenant}, nil
+    }))
+    child := NewContainer()
+    child.SetParent(parent)
+
+    acme, err := child.ResolveWith("connection", "acme")
+    require.NoError(t, err)
+    globex, err := child.ResolveWith("connection", "globex")
+    require.NoError(t, err)
+
+    assert.NotSame(t, acme, globex)
+    assert.Equal(t, "acme", acme.(*tenantConnection).tenant)
+    assert.Equal(t, "globex", globex.(*tenantConnection).tenant)
+
+    // Factory errors are returned; plain Resolve calls the factory without arguments
+    _, err = child.ResolveWith("connection", 42)
+    assert.EqualError(t, err, "failed to construct connection: tenant must be a string")
+    _, err = child.Resolve("connection")
+    assert.EqualError(t, err, "failed to construct connection: expected a tenant, got 0 arguments")
+
+    require.NoError(t, child.Register("plain", &TestStruct{}, Singleton))
+    _, err = child.ResolveWith("plain", "x")
+    assert.EqualError(t, err, "service plain is not a parameterized prototype")
+    _, err = child.ResolveWith("missing")
+    assert.EqualError(t, err, "no service found for qualifier: missing")
+}
@@ -0,0 +1,145 @@
+// pkg/container/plan.go
+package container
+
+import (
+    "fmt"
+    "reflect"
+)
+
+// PlannedField describes what InjectStruct would do with one di-tagged field
+type PlannedField struct {
+    Field       string       // Field name
+    Qualifier   string       // Qualifier after placeholder expansion
+    Required    bool         // Whether a missing service would fail the injection
+    Resolvable  bool         // Whether a service is registered for the qualifier
+    ServiceType reflect.Type // Registered concrete type, nil when unknown before resolving
+    Assignable  bool         // Whether ServiceType fits the field; false when unknown
+    Problem     string       // Why the field would fail or be skipped, empty when fine
+}
+
+// InjectionPlan is the result of PlanInjection
+type InjectionPlan struct {
+    Type   string         // Name of the planned struct type
+    Fields []PlannedField // Tagged, exported fields in declaration order
+}
+
+// Problems returns the fields that report a problem
+func (p *InjectionPlan) Problems() []PlannedField {
+    problems := make([]PlannedField, 0)
+    for _, field := range p.Fields {
+        if field.Problem != "" {
+            problems = append(problems, field)
+        }
+    }
+    return problems
+}
+
+// PlanInjection reports, without resolving or mutating anything, what InjectStruct would do
+// with target: for each tagged, exported field whether its service is registered and whether the
+// registered concrete type is assignable to the field, so type errors surface before a real
+// injection. Services built by untyped factories have no known type until they are resolved.
+// di:"all" and di:"prefix:..." fields are collections and are reported as resolvable.
+func (c *Container) PlanInjection(target interface{}) (*InjectionPlan, error) {
+    targetType := reflect.TypeOf(target)
+    if targetType == nil || targetType.Kind() != reflect.Ptr || targetType.Elem().Kind() != reflect.Struct {
+        c.log.Errorw("Cannot plan injection", "type", targetType)
+        return nil, fmt.Errorf("target must be a pointer to struct, got: %v", targetType)
+    }
+    targetType = targetType.Elem()
+
+    c.mu.RLock()
+    requiredByDefault := c.requiredByDefault
+    c.mu.RUnlock()
+
+    plan := &InjectionPlan{Type: targetType.Name(), Fields: make([]PlannedField, 0)}
+    for _, field := range injectionPlanFor(targetType) {
+        if !field.tagged || !field.exported {
+            continue
+        }
+        planned := PlannedField{
+            Field:     field.name,
+            Qualifier: field.qualifier,
+            Required:  field.isRequired(requiredByDefault),
+        }
+        if field.all || field.prefix != "" {
+            planned.Resolvable, planned.Assignable = true, true
+            plan.Fields = append(plan.Fields, planned)
+            continue
+        }
+
+        if hasPlaceholders(field.qualifier) {
+            expanded, err := c.expandQualifier(field.qualifier)
+            if err != nil {
+                planned.Problem = err.Error()
+                plan.Fields = append(plan.Fields, planned)
+                continue
+            }
+            planned.Qualifier = expanded
+        }
+
+        serviceType, found := c.registeredType(planned.Qualifier)
+        planned.Resolvable = found
+        planned.ServiceType = serviceType
+        fieldType := targetType.Field(field.index).Type
+        switch {
+        case !found && planned.Required:
+            planned.Problem = "required service is not registered"
+        case !found:
+            planned.Problem = "optional service is not registered and will be skipped"
+        case serviceType == nil:
+            planned.Problem = "service type is unknown until resolved"
+        default:
+            planned.Assignable = fitsField(serviceType, fieldType)
+            if !planned.Assignable {
+                planned.Problem = fmt.Sprintf("service type %v is not assignable to field type %v", serviceType, fieldType)
+            }
+        }
+        plan.Fields = append(plan.Fields, planned)
+    }
+
+    c.log.Debugw("Planned injection", "type", plan.Type, "fields", len(plan.Fields), "problems", len(plan.Problems()))
+    return plan, nil
+}
+
+// registeredType looks up the type of the service registered for qualifier, or of its active
+// profile variant, in this container or its ancestors; the cached instance's type wins over
+// the registered one
+func (c *Container) registeredType(qualifier string) (reflect.Type, bool) {
+    c.mu.RLock()
+    service, exists := c.services[qualifier]
+    parent := c.parent
+    var serviceType reflect.Type
+    if exists {
+        serviceType = service.Type
+        if service.Instance != nil {
+            serviceType = reflect.TypeOf(service.Instance)
+        }
+    }
+    c.mu.RUnlock()
+
+    if !exists {
+        if variant, ok, _ := c.activeVariant(qualifier); ok {
+            return c.registeredType(variant)
+        }
+    }
+    if !exists && parent != nil {
+        return parent.registeredType(qualifier)
+    }
+    return serviceType, exists
+}
+
+// fitsField reports whether InjectStruct can place a service of serviceType into a field of
+// fieldType without AllowConvertibleInjection
+func fitsField(serviceType, fieldType reflect.Type) bool {
+    switch {
+    case serviceType.AssignableTo(fieldType):
+        return true
+    case isProviderType(fieldType):
+        return serviceType.AssignableTo(fieldType.Out(0))
+    case isPointerToInterface(fieldType):
+        return serviceType.AssignableTo(fieldType.Elem())
+    default:
+        family := scalarFamily(serviceType.Kind())
+        return family != "" && family == scalarFamily(fieldType.Kind()) && serviceType.ConvertibleTo(fieldType)
+    }
+}
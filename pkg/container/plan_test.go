@@ -0,0 +1,51 @@
+package container
+
+import (
+    "reflect"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+type plannedTarget struct {
+    Service   TestService `di:"testService" required:"true"`
+    Notifier  notifier    `di:"wrongType"`
+    Retries   int64       `di:"retries"`
+    Missing   TestService `di:"missingService"`
+    Lazy      TestService `di:"untyped"`
+    Notifiers []notifier  `di:"all"`
+    internal  TestService `di:"testService"`
+}
+
+func TestContainer_PlanInjection(t *testing.T) {
+    container := NewContainer()
+    require.NoError(t, container.Register("testService", &testServiceImpl{}, Singleton))
+    require.NoError(t, container.Register("wrongType", &TestStruct{}, Singleton))
+    require.NoError(t, container.Register("retries", 3, Singleton))
+    require.NoError(t, container.RegisterPrototypeFactory("untyped", func() interface{} { return &testServiceImpl{} }))
+
+    target := &plannedTarget{}
+    plan, err := container.PlanInjection(target)
+    require.NoError(t, err)
+    assert.Equal(t, "plannedTarget", plan.Type)
+
+    serviceType := reflect.TypeOf(&testServiceImpl{})
+    assert.Equal(t, []PlannedField{
+        {Field: "Service", Qualifier: "testService", Required: true, Resolvable: true, ServiceType: serviceType, Assignable: true},
+        {Field: "Notifier", Qualifier: "wrongType", Resolvable: true, ServiceType: reflect.TypeOf(&TestStruct{}),
+            Problem: "service type *container.TestStruct is not assignable to field type container.notifier"},
+        {Field: "Retries", Qualifier: "retries", Resolvable: true, ServiceType: reflect.TypeOf(0), Assignable: true},
+        {Field: "Missing", Qualifier: "missingService", Problem: "optional service is not registered and will be skipped"},
+        {Field: "Lazy", Qualifier: "untyped", Resolvable: true, Problem: "service type is unknown until resolved"},
+        {Field: "Notifiers", Qualifier: "all", Resolvable: true, Assignable: true},
+    }, plan.Fields)
+    assert.Len(t, plan.Problems(), 3)
+
+    // Nothing was injected or built
+    assert.Nil(t, target.Service)
+    assert.Nil(t, target.Notifier)
+
+    _, err = container.PlanInjection(plannedTarget{})
+    assert.Error(t, err)
+}
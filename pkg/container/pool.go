@@ -0,0 +1,205 @@
+// pkg/container/pool.go
+package container
+
+import (
+    "fmt"
+)
+
+// instancePool holds idle, pre-built instances of a pooled prototype
+type instancePool struct {
+    idle chan interface{}
+    stop chan struct{} // Closed by discardPool; pending refills then drop what they build
+}
+
+// newInstancePool creates an empty pool holding up to size instances
+func newInstancePool(size int) *instancePool {
+    return &instancePool{idle: make(chan interface{}, size), stop: make(chan struct{})}
+}
+
+// stopped reports whether the pool was discarded
+func (p *instancePool) stopped() bool {
+    select {
+    case <-p.stop:
+        return true
+    default:
+        return false
+    }
+}
+
+// EnablePool turns the prototype registered under qualifier in this container into a pooled
+// one for latency-sensitive services: size instances are built up front and Resolve hands them
+// out, building a replacement in the background for each one taken. When the pool is empty,
+// Resolve does not block but builds a new instance on the spot. Callers may hand instances
+// back with Return; they are reused as they are, so a service must be reset before returning it.
+// Decorate refills the pool with decorated instances, PushOverride suspends pooling until the
+// override is restored, and Cleanup and Reset discard the pool and stop its background refills.
+func (c *Container) EnablePool(qualifier string, size int) error {
+    if size <= 0 {
+        c.log.Errorw("Invalid pool size", "qualifier", qualifier, "size", size)
+        return fmt.Errorf("pool size for %s must be positive, got %d", qualifier, size)
+    }
+
+    c.mu.RLock()
+    frozen := c.checkMutable("pool", qualifier)
+    scopedService, exists := c.services[qualifier]
+    _, pooled := c.pools[qualifier]
+    processors := c.postProcessors
+    c.mu.RUnlock()
+
+    if frozen != nil {
+        return frozen
+    }
+
+    if !exists {
+        c.log.Errorw("Cannot pool unknown service", "qualifier", qualifier)
+        return fmt.Errorf("no service found for qualifier: %s", qualifier)
+    }
+    if scopedService.Scope != Prototype || scopedService.parameterized != nil {
+        c.log.Errorw("Cannot pool service that is not a prototype", "qualifier", qualifier, "scope", scopedService.Scope)
+        return fmt.Errorf("cannot pool %s: scope is %s, not %s", qualifier, scopedService.Scope, Prototype)
+    }
+    if pooled {
+        c.log.Errorw("Pool already enabled", "qualifier", qualifier)
+        return fmt.Errorf("pool already enabled for qualifier: %s", qualifier)
+    }
+
+    // Factories may call back into the container, so warm up without holding the lock
+    pool := newInstancePool(size)
+    for i := 0; i < size; i++ {
+        instance, err := c.buildPrototype(qualifier, scopedService, processors)
+        if err != nil {
+            return fmt.Errorf("cannot warm up pool for %s: %w", qualifier, err)
+        }
+        pool.idle <- instance
+    }
+
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    if err := c.checkMutable("pool", qualifier); err != nil {
+        return err
+    }
+    if c.pools == nil {
+        c.pools = make(map[string]*instancePool)
+    }
+    if _, pooled := c.pools[qualifier]; pooled {
+        return fmt.Errorf("pool already enabled for qualifier: %s", qualifier)
+    }
+    c.pools[qualifier] = pool
+    c.log.Infow("Enabled prototype pool", "qualifier", qualifier, "size", size)
+    return nil
+}
+
+// Return hands an instance obtained from a pooled prototype back for reuse.
+// It is dropped when the pool is already full.
+func (c *Container) Return(qualifier string, instance interface{}) error {
+    if instance == nil {
+        return fmt.Errorf("cannot return nil instance to pool %s", qualifier)
+    }
+
+    c.mu.RLock()
+    pool, pooled := c.pools[qualifier]
+    c.mu.RUnlock()
+
+    if !pooled {
+        c.log.Errorw("Cannot return instance to service without pool", "qualifier", qualifier)
+        return fmt.Errorf("no pool enabled for qualifier: %s", qualifier)
+    }
+    select {
+    case pool.idle <- instance:
+        c.log.Debugw("Returned instance to pool", "qualifier", qualifier)
+    default:
+        c.log.Debugw("Pool is full, dropping returned instance", "qualifier", qualifier)
+    }
+    return nil
+}
+
+// resolvePooled takes an idle instance from the pool and refills it in the background, or
+// builds a new instance when the pool is empty
+func (c *Container) resolvePooled(qualifier string, scopedService *ScopedService, pool *instancePool, processors []PostProcessor) (interface{}, error) {
+    select {
+    case instance := <-pool.idle:
+        c.log.Debugw("Took instance from pool", "qualifier", qualifier)
+        go c.refillPool(qualifier, scopedService, pool, processors)
+        return instance, nil
+    default:
+        c.log.Debugw("Pool is empty, building instance", "qualifier", qualifier)
+        return c.buildPrototype(qualifier, scopedService, processors)
+    }
+}
+
+// refillPool builds one instance and adds it to the pool unless the pool filled up or was
+// discarded meanwhile
+func (c *Container) refillPool(qualifier string, scopedService *ScopedService, pool *instancePool, processors []PostProcessor) {
+    if pool.stopped() {
+        return
+    }
+    instance, err := c.buildPrototype(qualifier, scopedService, processors)
+    if err != nil {
+        c.log.Warnw("Cannot refill pool", "qualifier", qualifier, "error", err)
+        return
+    }
+    if pool.stopped() {
+        c.log.Debugw("Pool was discarded, dropping refilled instance", "qualifier", qualifier)
+        return
+    }
+    select {
+    case pool.idle <- instance:
+    default:
+    }
+}
+
+// discardPool removes the pool of qualifier, drops its idle instances and stops its pending
+// refills. It returns the size of the discarded pool, or 0 when there was none.
+// The caller must hold the write lock.
+func (c *Container) discardPool(qualifier string) int {
+    pool, pooled := c.pools[qualifier]
+    if !pooled {
+        return 0
+    }
+    delete(c.pools, qualifier)
+    close(pool.stop)
+    for drained := false; !drained; {
+        select {
+        case <-pool.idle:
+        default:
+            drained = true
+        }
+    }
+    c.log.Debugw("Discarded prototype pool", "qualifier", qualifier)
+    return cap(pool.idle)
+}
+
+// restartPool replaces the pool of qualifier, if any, with an empty one of the same size that
+// is filled in the background, so instances built before a change to the service are not
+// handed out. The caller must hold the write lock.
+func (c *Container) restartPool(qualifier string, scopedService *ScopedService) {
+    c.enablePoolAsync(qualifier, scopedService, c.discardPool(qualifier))
+}
+
+// enablePoolAsync installs an empty pool of size for qualifier and fills it in the background.
+// A size of 0 does nothing. The caller must hold the write lock.
+func (c *Container) enablePoolAsync(qualifier string, scopedService *ScopedService, size int) {
+    if size == 0 {
+        return
+    }
+    if c.pools == nil {
+        c.pools = make(map[string]*instancePool)
+    }
+    pool := newInstancePool(size)
+    c.pools[qualifier] = pool
+    processors := c.postProcessors
+    go func() {
+        for i := 0; i < size; i++ {
+            c.refillPool(qualifier, scopedService, pool, processors)
+        }
+    }()
+}
+
+// buildPrototype builds a new prototype instance and counts it
+func (c *Container) buildPrototype(qualifier string, scopedService *ScopedService, processors []PostProcessor) (interface{}, error) {
+    instance, err := c.build(qualifier, scopedService.Scope, scopedService.create, processors, c.decoratorsOf(scopedService))
+    if err == nil {
+        c.countMetric(func(m *containerMetrics) Counter { return m.prototypeCreations })
+    }
+    return instance, err
+}
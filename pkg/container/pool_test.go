@@ -0,0 +1,151 @@
+package container
+
+import (
+    "sync/atomic"
+    "testing"
+    "time"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+type pooledConnection struct {
+    id int64
+}
+
+type decoratedConnection struct {
+    inner *pooledConnection
+}
+
+func TestContainer_EnablePool(t *testing.T) {
+    container := NewContainer()
+
+    // Builds wait for release while gated, so refills can be held back
+    var built atomic.Int64
+    var gated atomic.Bool
+    release := make(chan struct{})
+    require.NoError(t, container.RegisterPrototypeFactory("connection", func() interface{} {
+        if gated.Load() {
+            <-release
+        }
+        return &pooledConnection{id: built.Add(1)}
+    }))
+
+    require.NoError(t, container.EnablePool("connection", 2))
+    assert.Equal(t, int64(2), built.Load(), "the pool is warmed up front")
+
+    gated.Store(true)
+    first, err := container.Resolve("connection")
+    require.NoError(t, err)
+    second, err := container.Resolve("connection")
+    require.NoError(t, err)
+    assert.Equal(t, int64(1), first.(*pooledConnection).id)
+    assert.Equal(t, int64(2), second.(*pooledConnection).id)
+
+    // A returned instance is handed out again
+    require.NoError(t, container.Return("connection", first))
+    recycled, err := container.Resolve("connection")
+    require.NoError(t, err)
+    assert.Same(t, first, recycled)
+
+    // The pool is empty and its refills are pending, so the next resolve builds on the spot
+    gated.Store(false)
+    third, err := container.Resolve("connection")
+    require.NoError(t, err)
+    assert.Equal(t, int64(3), third.(*pooledConnection).id)
+
+    // The pending refills fill the pool again in the background
+    close(release)
+    assert.Eventually(t, func() bool {
+        container.mu.RLock()
+        defer container.mu.RUnlock()
+        return len(container.pools["connection"].idle) == 2
+    }, time.Second, time.Millisecond)
+}
+
+func TestContainer_EnablePoolErrors(t *testing.T) {
+    container := NewContainer()
+    require.NoError(t, container.Register("singleton", &TestStruct{}, Singleton))
+    require.NoError(t, container.RegisterPrototypeFactory("prototype", func() interface{} { return &TestStruct{} }))
+
+    assert.ErrorContains(t, container.EnablePool("missing", 2), "no service found for qualifier: missing")
+    assert.ErrorContains(t, container.EnablePool("singleton", 2), "scope is Singleton, not Prototype")
+    assert.ErrorContains(t, container.EnablePool("prototype", 0), "must be positive")
+    assert.ErrorContains(t, container.Return("prototype", &TestStruct{}), "no pool enabled")
+
+    require.NoError(t, container.EnablePool("prototype", 1))
+    assert.ErrorContains(t, container.EnablePool("prototype", 1), "pool already enabled")
+}
+
+func TestContainer_PoolFollowsServiceChanges(t *testing.T) {
+    container := NewContainer()
+    var built atomic.Int64
+    require.NoError(t, container.RegisterPrototypeFactory("connection", func() interface{} {
+        return &pooledConnection{id: built.Add(1)}
+    }))
+    require.NoError(t, container.EnablePool("connection", 2))
+
+    idle := func() int {
+        container.mu.RLock()
+        defer container.mu.RUnlock()
+        pool, pooled := container.pools["connection"]
+        if !pooled {
+            return -1
+        }
+        return len(pool.idle)
+    }
+
+    // Decorating drops the undecorated instances and refills with decorated ones
+    require.NoError(t, container.Decorate("connection", func(inner interface{}) interface{} {
+        return &decoratedConnection{inner: inner.(*pooledConnection)}
+    }))
+    assert.Eventually(t, func() bool { return idle() == 2 }, time.Second, time.Millisecond)
+    resolved, err := container.Resolve("connection")
+    require.NoError(t, err)
+    assert.IsType(t, &decoratedConnection{}, resolved)
+
+    // An override suspends the pool, restoring it refills the pool
+    mock := &pooledConnection{id: -1}
+    restore := container.PushOverride("connection", mock)
+    assert.Equal(t, -1, idle())
+    resolved, err = container.Resolve("connection")
+    require.NoError(t, err)
+    assert.Same(t, mock, resolved)
+    restore()
+    assert.Eventually(t, func() bool { return idle() == 2 }, time.Second, time.Millisecond)
+
+    // Cleanup discards the pool and its refills stop
+    container.mu.RLock()
+    pool := container.pools["connection"]
+    container.mu.RUnlock()
+    require.NoError(t, container.Cleanup())
+    assert.Equal(t, -1, idle())
+    assert.True(t, pool.stopped())
+    assert.Empty(t, pool.idle)
+    before := built.Load()
+    container.refillPool("connection", container.services["connection"], pool, nil)
+    assert.Equal(t, before, built.Load(), "a discarded pool is not refilled")
+}
+
+func TestContainer_ResetDiscardsPools(t *testing.T) {
+    container := NewContainer()
+    require.NoError(t, container.RegisterPrototypeFactory("connection", func() interface{} {
+        return &pooledConnection{}
+    }))
+    require.NoError(t, container.EnablePool("connection", 1))
+
+    container.mu.RLock()
+    pool := container.pools["connection"]
+    container.mu.RUnlock()
+    require.NoError(t, container.Reset())
+    assert.True(t, pool.stopped())
+    assert.Empty(t, container.pools)
+
+    // A service registered again under the same qualifier is not served from the old pool
+    require.NoError(t, container.RegisterPrototypeFactory("connection", func() interface{} {
+        return &TestStruct{}
+    }))
+    resolved, err := container.Resolve("connection")
+    require.NoError(t, err)
+    assert.IsType(t, &TestStruct{}, resolved)
+}
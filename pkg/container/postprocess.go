@@ -0,0 +1,48 @@
+// pkg/container/postprocess.go
+package container
+
+import (
+    "fmt"
+)
+
+// PostProcessor transforms a service after it has been constructed and post-constructed,
+// similar to Spring's BeanPostProcessor. The returned value replaces the instance, so a
+// processor may return the instance unchanged, modify it, or wrap it (e.g. in a proxy).
+type PostProcessor func(qualifier string, instance interface{}) (interface{}, error)
+
+// AddPostProcessor appends a post-processor. Processors run in the order they were added,
+// each receiving the previous one's result, for every instance the container constructs
+// afterwards: eager singletons on Register, lazy singletons on first resolve and prototypes
+// on every resolve. Processors may run with the container locked and must not call back into it.
+// It panics on a frozen container.
+func (c *Container) AddPostProcessor(processor PostProcessor) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+
+    if err := c.checkMutable("add", "post-processor"); err != nil {
+        panic(err)
+    }
+
+    // Copy on write so resolves holding the previous slice are unaffected
+    processors := make([]PostProcessor, len(c.postProcessors), len(c.postProcessors)+1)
+    copy(processors, c.postProcessors)
+    c.postProcessors = append(processors, processor)
+    c.log.Infow("Added post-processor", "count", len(c.postProcessors))
+}
+
+// postProcess runs processors over a newly constructed instance
+func (c *Container) postProcess(qualifier string, instance interface{}, processors []PostProcessor) (interface{}, error) {
+    for i, processor := range processors {
+        processed, err := processor(qualifier, instance)
+        if err != nil {
+            c.log.Errorw("Post-processor failed", "qualifier", qualifier, "processor", i, "error", err)
+            return nil, fmt.Errorf("post-processor %d failed for %s: %w", i, qualifier, err)
+        }
+        if processed == nil {
+            c.log.Errorw("Post-processor returned nil", "qualifier", qualifier, "processor", i)
+            return nil, fmt.Errorf("post-processor %d returned nil for %s", i, qualifier)
+        }
+        instance = processed
+    }
+    return instance, nil
+}
@@ -0,0 +1,45 @@
+package container
+
+import (
+    "errors"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+func TestContainer_AddPostProcessor(t *testing.T) {
+    container := NewContainer()
+    seen := make([]string, 0)
+    container.AddPostProcessor(func(qualifier string, instance interface{}) (interface{}, error) {
+        seen = append(seen, qualifier)
+        return &wrappingGreeter{inner: instance.(greeter), prefix: "proxy:"}, nil
+    })
+    container.AddPostProcessor(func(qualifier string, instance interface{}) (interface{}, error) {
+        return &wrappingGreeter{inner: instance.(greeter), prefix: "audit:"}, nil
+    })
+
+    require.NoError(t, container.Register("singleton", &plainGreeter{}, Singleton))
+    require.NoError(t, container.RegisterPrototypeFactory("prototype", func() interface{} {
+        return &plainGreeter{}
+    }))
+
+    for _, qualifier := range []string{"singleton", "prototype"} {
+        resolved, err := container.Resolve(qualifier)
+        require.NoError(t, err)
+        wrapper, ok := resolved.(*wrappingGreeter)
+        require.True(t, ok, "resolved value for %s should be the wrapper", qualifier)
+        assert.Equal(t, "audit:proxy:hello", wrapper.Greet())
+    }
+    assert.Equal(t, []string{"singleton", "prototype"}, seen)
+}
+
+func TestContainer_AddPostProcessorErrors(t *testing.T) {
+    container := NewContainer()
+    container.AddPostProcessor(func(qualifier string, instance interface{}) (interface{}, error) {
+        return nil, errors.New("rejected")
+    })
+
+    err := container.Register("singleton", &plainGreeter{}, Singleton)
+    assert.EqualError(t, err, "post-processor 0 failed for singleton: rejected")
+}
@@ -0,0 +1,89 @@
+// pkg/container/primary.go
+package container
+
+import (
+    "fmt"
+    "reflect"
+    "sort"
+    "strings"
+)
+
+// RegisterPrimary registers a service and marks it as the primary candidate for its types.
+// When type-based resolution finds several matching services, the primary one wins.
+func (c *Container) RegisterPrimary(qualifier string, service interface{}, scope Scope) error {
+    return c.register(qualifier, service, scope, func(s *ScopedService) {
+        s.Primary = true
+    })
+}
+
+// ResolveByType resolves the single service whose registered type is assignable to t.
+// If several services match, exactly one of them must be registered as primary.
+// Parent containers are searched only when no service in this container matches.
+func (c *Container) ResolveByType(t reflect.Type) (interface{}, error) {
+    qualifier, err := c.qualifierForType(t)
+    if err != nil {
+        return nil, err
+    }
+    if qualifier == "" {
+        c.mu.RLock()
+        parent := c.parent
+        c.mu.RUnlock()
+        if parent != nil {
+            return parent.ResolveByType(t)
+        }
+        c.log.Errorw("No service found for type", "type", t)
+        return nil, fmt.Errorf("no service found for type: %v", t)
+    }
+    return c.Resolve(qualifier)
+}
+
+// qualifierForType picks the qualifier that type-based resolution should use for t,
+// returning an empty qualifier when nothing in this container matches.
+// Conditional services whose condition does not match are not candidates, as in Resolve.
+func (c *Container) qualifierForType(t reflect.Type) (string, error) {
+    c.mu.RLock()
+    matches := make(map[string]*ScopedService)
+    for qualifier, service := range c.services {
+        if service.Type != nil && service.Type.AssignableTo(t) {
+            matches[qualifier] = service
+        }
+    }
+    c.mu.RUnlock()
+
+    // Conditions may call back into the container, so evaluate them without holding the lock
+    candidates := make([]string, 0, len(matches))
+    primaries := make([]string, 0)
+    for qualifier, service := range matches {
+        if service.Condition != nil && !service.Condition.Matches(c) {
+            continue
+        }
+        candidates = append(candidates, qualifier)
+        if service.Primary {
+            primaries = append(primaries, qualifier)
+        }
+    }
+    sort.Strings(candidates)
+    sort.Strings(primaries)
+
+    c.log.Debugw("Resolving service by type",
+        "type", t,
+        "candidates", candidates,
+        "primaries", primaries)
+
+    switch {
+    case len(candidates) == 0:
+        return "", nil
+    case len(candidates) == 1:
+        return candidates[0], nil
+    case len(primaries) == 1:
+        return primaries[0], nil
+    case len(primaries) == 0:
+        c.log.Errorw("Ambiguous type resolution", "type", t, "candidates", candidates)
+        return "", fmt.Errorf("multiple services match type %v and none is primary: %s",
+            t, strings.Join(candidates, ", "))
+    default:
+        c.log.Errorw("Multiple primary services", "type", t, "primaries", primaries)
+        return "", fmt.Errorf("multiple primary services match type %v: %s",
+            t, strings.Join(primaries, ", "))
+    }
+}
@@ -0,0 +1,73 @@
+package container
+
+import (
+    "reflect"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+var testServiceType = reflect.TypeOf((*TestService)(nil)).Elem()
+
+func TestContainer_ResolveByTypePrimary(t *testing.T) {
+    container := NewContainer()
+    secondary := &testServiceImpl{name: "secondary"}
+    primary := &testServiceImpl{name: "primary"}
+
+    require.NoError(t, container.Register("secondaryService", secondary, Singleton))
+    require.NoError(t, container.RegisterPrimary("primaryService", primary, Singleton))
+
+    resolved, err := container.ResolveByType(testServiceType)
+    require.NoError(t, err)
+    assert.Same(t, primary, resolved)
+}
+
+func TestContainer_ResolveByTypeAmbiguous(t *testing.T) {
+    container := NewContainer()
+
+    // No match at all
+    _, err := container.ResolveByType(testServiceType)
+    assert.ErrorContains(t, err, "no service found for type")
+
+    // A single match needs no primary
+    only := &testServiceImpl{name: "only"}
+    require.NoError(t, container.Register("onlyService", only, Singleton))
+    resolved, err := container.ResolveByType(testServiceType)
+    require.NoError(t, err)
+    assert.Same(t, only, resolved)
+
+    // Several matches without a primary
+    require.NoError(t, container.Register("otherService", &testServiceImpl{name: "other"}, Singleton))
+    _, err = container.ResolveByType(testServiceType)
+    assert.ErrorContains(t, err, "none is primary")
+
+    // Several primaries
+    require.NoError(t, container.RegisterPrimary("firstPrimary", &testServiceImpl{name: "p1"}, Singleton))
+    require.NoError(t, container.RegisterPrimary("secondPrimary", &testServiceImpl{name: "p2"}, Singleton))
+    _, err = container.ResolveByType(testServiceType)
+    assert.ErrorContains(t, err, "multiple primary services")
+}
+
+func TestContainer_ResolveByTypeSkipsInactiveConditions(t *testing.T) {
+    container := NewContainer()
+    active := &testServiceImpl{name: "active"}
+    inactive := &testServiceImpl{name: "inactive"}
+    never := func(*Container) bool { return false }
+    always := func(*Container) bool { return true }
+
+    require.NoError(t, container.RegisterIf("activeService", active, Singleton, always))
+    require.NoError(t, container.RegisterIf("inactiveService", inactive, Singleton, never))
+
+    // The inactive implementation does not make the type ambiguous
+    resolved, err := container.ResolveByType(testServiceType)
+    require.NoError(t, err)
+    assert.Same(t, active, resolved)
+
+    // Nor does an inactive primary win over the active implementation
+    require.NoError(t, container.RegisterConditional("inactivePrimary", &testServiceImpl{name: "primary"}, Singleton, ConditionFunc(never)))
+    container.services["inactivePrimary"].Primary = true
+    resolved, err = container.ResolveByType(testServiceType)
+    require.NoError(t, err)
+    assert.Same(t, active, resolved)
+}
@@ -1,6 +1,8 @@
 // pkg/container/profile.go
 package container
 
+import "sync"
+
 // Profile represents a configuration profile for the container
 type Profile struct {
     Name    string  // Profile identifier
@@ -11,10 +13,44 @@ type Profile struct {
 
 // ProfileManager handles profile management and activation
 type ProfileManager struct {
+    mu       sync.RWMutex         // Guards active independently of the container lock
     profiles map[string]*Profile  // Map of available profiles
     active   []string            // List of currently active profiles
 }
 
+// Active returns a copy of the currently active profiles
+func (pm *ProfileManager) Active() []string {
+    pm.mu.RLock()
+    defer pm.mu.RUnlock()
+    return append([]string(nil), pm.active...)
+}
+
+// SetActive replaces the active profiles
+func (pm *ProfileManager) SetActive(profiles []string) {
+    pm.mu.Lock()
+    defer pm.mu.Unlock()
+    pm.active = append(make([]string, 0, len(profiles)), profiles...)
+}
+
+// IsActive reports whether a profile is currently active
+func (pm *ProfileManager) IsActive(profileName string) bool {
+    pm.mu.RLock()
+    defer pm.mu.RUnlock()
+    for _, active := range pm.active {
+        if active == profileName {
+            return true
+        }
+    }
+    return false
+}
+
+// ProfileAware is implemented by services that need the container's active profiles.
+// SetProfiles is called before PostConstruct whenever the container constructs the service,
+// and on a cached singleton by ResolveWithProfiles when the active profiles changed since.
+type ProfileAware interface {
+    SetProfiles(profiles []string)
+}
+
 // Condition defines an interface for conditional bean creation/activation
 type Condition interface {
     // Matches checks if the condition is satisfied for the given container
@@ -31,3 +67,93 @@ func (pc *ProfileCondition) Matches(container *Container) bool {
     return container.IsProfileActive(pc.ProfileName)
 }
 
+
+// ResolveWithProfiles resolves a service like Resolve and makes sure a cached ProfileAware
+// singleton reflects this container's active profiles. Instances are handed the profiles before
+// PostConstruct when they are built; a singleton built or last refreshed under another profile
+// set, possibly in an ancestor container, gets SetProfiles again. PostConstruct is not re-run,
+// so a service deriving state from its profiles should update it in SetProfiles. The undecorated
+// instance of a decorated service is the one refreshed, and external instances are left alone.
+func (c *Container) ResolveWithProfiles(qualifier string) (interface{}, error) {
+    instance, err := c.Resolve(qualifier)
+    if err != nil {
+        return nil, err
+    }
+
+    owner, service, err := c.cachedService(qualifier)
+    if err != nil || service == nil {
+        return instance, err
+    }
+
+    profiles := c.profileManager.Active()
+    owner.mu.Lock()
+    target := service.destroyable()
+    profileAware, ok := target.(ProfileAware)
+    refresh := ok && !service.external && !sameProfiles(service.profiles, profiles)
+    if refresh {
+        service.profiles = profiles
+    }
+    owner.mu.Unlock()
+
+    if refresh {
+        c.log.Infow("Refreshing profiles of cached service", "qualifier", qualifier, "profiles", profiles)
+        profileAware.SetProfiles(profiles)
+    }
+    return instance, nil
+}
+
+// cachedService finds the cached singleton Resolve returns for qualifier, following profile
+// variants and the parent chain the same way, and the container holding it. service is nil
+// when the resolved service is not cached, e.g. a prototype.
+func (c *Container) cachedService(qualifier string) (owner *Container, service *ScopedService, err error) {
+    for current := c; current != nil; {
+        current.mu.RLock()
+        scopedService, exists := current.services[qualifier]
+        parent := current.parent
+        current.mu.RUnlock()
+
+        if exists && (scopedService.Condition == nil || scopedService.Condition.Matches(current)) {
+            if !scopedService.Scope.caches() {
+                return nil, nil, nil
+            }
+            return current, scopedService, nil
+        }
+        variant, ok, err := current.activeVariant(qualifier)
+        if err != nil {
+            return nil, nil, err
+        }
+        if ok {
+            return current.cachedService(variant)
+        }
+        current = parent
+    }
+    return nil, nil, nil
+}
+
+// sameProfiles reports whether two profile lists hold the same profiles, ignoring order
+func sameProfiles(a, b []string) bool {
+    if len(a) != len(b) {
+        return false
+    }
+    counts := make(map[string]int, len(a))
+    for _, profile := range a {
+        counts[profile]++
+    }
+    for _, profile := range b {
+        if counts[profile] == 0 {
+            return false
+        }
+        counts[profile]--
+    }
+    return true
+}
+
+// WithProfiles activates profiles for the duration of fn and then restores the previously
+// active profiles, even if fn panics. Conditional services are re-evaluated on both changes.
+func (c *Container) WithProfiles(profiles []string, fn func() error) error {
+    previous := c.profileManager.Active()
+    c.SetActiveProfiles(profiles...)
+    defer c.SetActiveProfiles(previous...)
+
+    return fn()
+}
@@ -0,0 +1,44 @@
+// pkg/container/provider.go
+package container
+
+import (
+    "fmt"
+    "reflect"
+)
+
+// isProviderType reports whether t is a provider function, func() T or func() (T, error),
+// which InjectStruct fills with a getter resolving the service on each call
+func isProviderType(t reflect.Type) bool {
+    if t.Kind() != reflect.Func || t.NumIn() != 0 || t.IsVariadic() {
+        return false
+    }
+    return t.NumOut() == 1 || (t.NumOut() == 2 && t.Out(1) == errorType)
+}
+
+// makeProvider returns a function of providerType that resolves qualifier on every call.
+// A func() (T, error) provider reports failures as its error; a func() T provider panics,
+// since it has no other way to report them.
+func (c *Container) makeProvider(qualifier string, providerType reflect.Type) reflect.Value {
+    serviceType := providerType.Out(0)
+    returnsError := providerType.NumOut() == 2
+
+    return reflect.MakeFunc(providerType, func([]reflect.Value) []reflect.Value {
+        instance, err := c.Resolve(qualifier)
+        if err == nil && !reflect.TypeOf(instance).AssignableTo(serviceType) {
+            err = fmt.Errorf("service %s of type %T is not assignable to %v", qualifier, instance, serviceType)
+        }
+        if err != nil {
+            c.log.Errorw("Provider failed to resolve service", "qualifier", qualifier, "error", err)
+            if !returnsError {
+                panic(fmt.Errorf("provider for %s: %w", qualifier, err))
+            }
+            return []reflect.Value{reflect.Zero(serviceType), reflect.ValueOf(&err).Elem()}
+        }
+
+        result := reflect.ValueOf(instance)
+        if returnsError {
+            return []reflect.Value{result.Convert(serviceType), reflect.Zero(errorType)}
+        }
+        return []reflect.Value{result.Convert(serviceType)}
+    })
+}
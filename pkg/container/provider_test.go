@@ -0,0 +1,53 @@
+package container
+
+import (
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+type paymentProcessor interface {
+    Charge(amount int) string
+}
+
+type cardProcessor struct{}
+
+func (p *cardProcessor) Charge(amount int) string { return "charged" }
+
+type checkout struct {
+    Payments func() paymentProcessor  `di:"paymentService" required:"true"`
+    Notifier func() (notifier, error) `di:"notifier"`
+    Missing  func() paymentProcessor  `di:"missingService"`
+}
+
+func TestContainer_InjectProvider(t *testing.T) {
+    container := NewContainer()
+    resolved := 0
+    require.NoError(t, container.RegisterPrototypeFactory("paymentService", func() interface{} {
+        resolved++
+        return &cardProcessor{}
+    }))
+    require.NoError(t, container.Register("notifier", &TestStruct{}, Singleton))
+
+    target := &checkout{}
+    require.NoError(t, container.InjectStruct(target))
+    assert.Zero(t, resolved, "nothing is resolved at injection time")
+    assert.Nil(t, target.Missing, "optional providers of absent services stay nil")
+
+    processor := target.Payments()
+    assert.Equal(t, "charged", processor.Charge(10))
+    assert.Equal(t, 1, resolved)
+    target.Payments()
+    assert.Equal(t, 2, resolved, "every call resolves again")
+
+    // The registered notifier has the wrong type, reported when the provider is called
+    _, err := target.Notifier()
+    assert.ErrorContains(t, err, "service notifier of type *container.TestStruct is not assignable to container.notifier")
+}
+
+func TestContainer_InjectProviderRequired(t *testing.T) {
+    container := NewContainer()
+    err := container.InjectStruct(&checkout{})
+    assert.EqualError(t, err, "required service not found for field Payments: no service found for qualifier: paymentService")
+}
@@ -0,0 +1,100 @@
+// pkg/container/proxy.go
+package container
+
+import (
+    "fmt"
+    "reflect"
+
+    "di-extended/pkg/aop"
+)
+
+// InvokeFunc calls a method of the proxied service by name through Invoke, so every matching
+// aspect runs around it
+type InvokeFunc func(method string, args ...interface{}) ([]interface{}, error)
+
+// proxyFactory builds a proxy around target for one interface type
+type proxyFactory func(target interface{}, invoke InvokeFunc) interface{}
+
+// RegisterProxy registers how to build an aspect proxy implementing the interface T. Go cannot
+// implement an interface at runtime, so the proxy is a small hand-written or generated type
+// whose methods forward to invoke, e.g.
+//
+//     func (p *userServiceProxy) GetUser(id int) string {
+//         results, _ := p.invoke("GetUser", id)
+//         return results[0].(string)
+//     }
+//
+// With EnableAspectProxies, InjectStruct injects such a proxy into fields of type T.
+func RegisterProxy[T any](c *Container, factory func(target T, invoke InvokeFunc) T) error {
+    iface := reflect.TypeOf((*T)(nil)).Elem()
+    if iface.Kind() != reflect.Interface {
+        c.log.Errorw("Cannot register proxy for non-interface type", "type", iface)
+        return fmt.Errorf("cannot register proxy for %v: not an interface", iface)
+    }
+    if factory == nil {
+        c.log.Errorw("Cannot register nil proxy factory", "type", iface)
+        return fmt.Errorf("cannot register nil proxy factory for %v", iface)
+    }
+
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    if err := c.checkMutable("register proxy for", iface.String()); err != nil {
+        return err
+    }
+    if c.proxyFactories == nil {
+        c.proxyFactories = make(map[reflect.Type]proxyFactory)
+    }
+    c.proxyFactories[iface] = func(target interface{}, invoke InvokeFunc) interface{} {
+        return factory(target.(T), invoke)
+    }
+    c.log.Infow("Registered aspect proxy", "type", iface)
+    return nil
+}
+
+// EnableAspectProxies makes InjectStruct inject an aspect proxy instead of the raw service into
+// an interface field when any registered aspect's pointcut matches one of the service's methods
+// and a proxy for the field's interface was registered with RegisterProxy. Calls through the
+// field then run the aspects without an explicit Invoke. Disabled by default.
+// It panics on a frozen container.
+func (c *Container) EnableAspectProxies(enabled bool) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    if err := c.checkMutable("set", "aspect proxies"); err != nil {
+        panic(err)
+    }
+    c.aspectProxies = enabled
+    c.log.Infow("Set aspect proxies", "enabled", enabled)
+}
+
+// proxyFor returns the aspect proxy of service for a field of fieldType, or service itself when
+// no aspect applies to it or no proxy is registered for the field type
+func (c *Container) proxyFor(qualifier string, service interface{}, fieldType reflect.Type) interface{} {
+    serviceType := reflect.TypeOf(service)
+    if fieldType.Kind() != reflect.Interface || !serviceType.AssignableTo(fieldType) {
+        return service
+    }
+
+    c.mu.RLock()
+    factory, registered := c.proxyFactories[fieldType]
+    advised := false
+    for i := 0; i < serviceType.NumMethod() && !advised; i++ {
+        jp := &aop.JoinPoint{Target: service, Method: serviceType.Method(i)}
+        advised = len(c.aspectManager.GetMatchingAspects(jp)) > 0
+    }
+    c.mu.RUnlock()
+
+    if !advised {
+        return service
+    }
+    if !registered {
+        c.log.Warnw("Aspects match service but no proxy is registered for the field type",
+            "qualifier", qualifier,
+            "fieldType", fieldType)
+        return service
+    }
+
+    c.log.Debugw("Injecting aspect proxy", "qualifier", qualifier, "fieldType", fieldType)
+    return factory(service, func(method string, args ...interface{}) ([]interface{}, error) {
+        return c.Invoke(service, method, args...)
+    })
+}
@@ -0,0 +1,82 @@
+package container
+
+import (
+    "testing"
+
+    "di-extended/pkg/aop"
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+type welcomer interface {
+    Welcome(name string) (string, error)
+}
+
+type englishWelcomer struct{}
+
+func (g *englishWelcomer) Welcome(name string) (string, error) { return "welcome " + name, nil }
+
+// welcomerProxy forwards to the container so aspects run around every call
+type welcomerProxy struct {
+    invoke InvokeFunc
+}
+
+func (p *welcomerProxy) Welcome(name string) (string, error) {
+    results, err := p.invoke("Welcome", name)
+    if err != nil {
+        return "", err
+    }
+    return results[0].(string), nil
+}
+
+type welcomerClient struct {
+    Welcomer welcomer `di:"welcomer"`
+}
+
+func TestContainer_EnableAspectProxies(t *testing.T) {
+    container := NewContainer()
+    raw := &englishWelcomer{}
+    require.NoError(t, container.Register("welcomer", raw, Singleton))
+    require.NoError(t, RegisterProxy[welcomer](container, func(target welcomer, invoke InvokeFunc) welcomer {
+        return &welcomerProxy{invoke: invoke}
+    }))
+    aspect := &recordingAspect{kind: aop.Before, pointcut: "englishWelcomer.Welcome"}
+    container.AddAspect(aspect)
+
+    // Disabled: the raw service is injected and aspects only run through Invoke
+    client := &welcomerClient{}
+    require.NoError(t, container.InjectStruct(client))
+    assert.Same(t, raw, client.Welcomer)
+
+    container.EnableAspectProxies(true)
+    require.NoError(t, container.InjectStruct(client))
+    require.IsType(t, &welcomerProxy{}, client.Welcomer)
+
+    welcome, err := client.Welcomer.Welcome("bob")
+    require.NoError(t, err)
+    assert.Equal(t, "welcome bob", welcome)
+    assert.Equal(t, 1, aspect.calls, "the aspect runs on a plain method call")
+}
+
+func TestContainer_EnableAspectProxiesWithoutMatchingAspect(t *testing.T) {
+    container := NewContainer()
+    raw := &englishWelcomer{}
+    require.NoError(t, container.Register("welcomer", raw, Singleton))
+    require.NoError(t, RegisterProxy[welcomer](container, func(target welcomer, invoke InvokeFunc) welcomer {
+        return &welcomerProxy{invoke: invoke}
+    }))
+    container.AddAspect(&recordingAspect{kind: aop.Before, pointcut: "userService.*"})
+    container.EnableAspectProxies(true)
+
+    client := &welcomerClient{}
+    require.NoError(t, container.InjectStruct(client))
+    assert.Same(t, raw, client.Welcomer, "services without matching aspects are injected as-is")
+}
+
+func TestRegisterProxyErrors(t *testing.T) {
+    container := NewContainer()
+    assert.ErrorContains(t, RegisterProxy[*englishWelcomer](container, func(target *englishWelcomer, invoke InvokeFunc) *englishWelcomer {
+        return target
+    }), "not an interface")
+    assert.ErrorContains(t, RegisterProxy[welcomer](container, nil), "nil proxy factory")
+}
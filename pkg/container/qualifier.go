@@ -0,0 +1,135 @@
+// pkg/container/qualifier.go
+package container
+
+import (
+    "errors"
+    "fmt"
+    "regexp"
+    "strings"
+)
+
+// envPlaceholder is the placeholder substituted with the active profile
+const envPlaceholder = "env"
+
+// placeholderPattern matches ${name} placeholders in di tag qualifiers
+var placeholderPattern = regexp.MustCompile(`\$\{([^}]*)\}`)
+
+// SetProperty sets a property available to ${name} placeholders in di tag qualifiers.
+// A property named "env" takes precedence over the active profile.
+// Watchers of the property registered with WatchProperty fire when its value changes.
+// It panics on a frozen container.
+func (c *Container) SetProperty(name, value string) {
+    c.mu.Lock()
+    if err := c.checkMutable("set property", name); err != nil {
+        c.mu.Unlock()
+        panic(err)
+    }
+    previous, existed := c.properties[name]
+    // Copy on write so expandQualifier can read a snapshot without the lock
+    properties := make(map[string]string, len(c.properties)+1)
+    for key, existing := range c.properties {
+        properties[key] = existing
+    }
+    properties[name] = value
+    c.properties = properties
+    watchers := c.propertyWatchers[name]
+    c.mu.Unlock()
+
+    c.log.Infow("Set property", "name", name, "value", value)
+    if existed && previous == value {
+        return
+    }
+    // Watchers run without the lock so they may resolve services or set other properties
+    for _, watcher := range watchers {
+        watcher(value)
+    }
+}
+
+// WatchProperty registers fn to be called with the new value whenever SetProperty changes the
+// property key, e.g. to hot-reload a scalar setting. Watchers run synchronously in the order
+// they were registered; setting a property to its current value does not fire them.
+// It panics on a frozen container.
+func (c *Container) WatchProperty(key string, fn func(newValue string)) {
+    if fn == nil {
+        c.log.Warnw("Ignoring nil property watcher", "key", key)
+        return
+    }
+
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    if err := c.checkMutable("watch property", key); err != nil {
+        panic(err)
+    }
+    if c.propertyWatchers == nil {
+        c.propertyWatchers = make(map[string][]func(string))
+    }
+    // A new slice keeps the one SetProperty may be iterating untouched
+    watchers := c.propertyWatchers[key]
+    c.propertyWatchers[key] = append(watchers[:len(watchers):len(watchers)], fn)
+    c.log.Infow("Watching property", "key", key, "watchers", len(watchers)+1)
+}
+
+// hasPlaceholders reports whether a qualifier contains ${name} placeholders
+func hasPlaceholders(qualifier string) bool {
+    return placeholderPattern.MatchString(qualifier)
+}
+
+// expandQualifier substitutes the ${name} placeholders of a qualifier. A name is looked up in the
+// container's properties; ${env} otherwise stands for the active profile and requires exactly one.
+func (c *Container) expandQualifier(qualifier string) (string, error) {
+    c.mu.RLock()
+    properties := c.properties
+    c.mu.RUnlock()
+
+    var errs []error
+    expanded := placeholderPattern.ReplaceAllStringFunc(qualifier, func(placeholder string) string {
+        name := placeholderPattern.FindStringSubmatch(placeholder)[1]
+        if value, ok := properties[name]; ok {
+            return value
+        }
+        if name == envPlaceholder {
+            active := c.profileManager.Active()
+            if len(active) == 1 {
+                return active[0]
+            }
+            errs = append(errs, fmt.Errorf("placeholder ${env} needs exactly one active profile, got %v", active))
+            return placeholder
+        }
+        errs = append(errs, fmt.Errorf("unresolved placeholder %s", placeholder))
+        return placeholder
+    })
+    if len(errs) > 0 {
+        c.log.Errorw("Cannot expand qualifier", "qualifier", qualifier, "errors", errs)
+        return "", fmt.Errorf("cannot expand qualifier %q: %w", qualifier, errors.Join(errs...))
+    }
+    return expanded, nil
+}
+
+// SetQualifierPattern enables strict qualifier checking: registrations whose qualifier does not
+// match pattern are rejected, e.g. regexp.MustCompile(`^[A-Za-z][A-Za-z0-9.-]*$`).
+// Passing nil turns strict checking off again. Blank qualifiers are always rejected.
+// It panics on a frozen container.
+func (c *Container) SetQualifierPattern(pattern *regexp.Regexp) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    if err := c.checkMutable("set", "qualifier pattern"); err != nil {
+        panic(err)
+    }
+    c.qualifierPattern = pattern
+    c.log.Infow("Set qualifier pattern", "pattern", pattern)
+}
+
+// validateQualifier checks a qualifier at registration time. The caller must hold the lock.
+func (c *Container) validateQualifier(qualifier string) error {
+    if strings.TrimSpace(qualifier) == "" {
+        c.log.Errorw("Invalid qualifier", "qualifier", qualifier)
+        return fmt.Errorf("qualifier must not be empty or blank: %q", qualifier)
+    }
+    if c.qualifierPattern != nil && !c.qualifierPattern.MatchString(qualifier) {
+        c.log.Errorw("Qualifier does not match pattern",
+            "qualifier", qualifier,
+            "pattern", c.qualifierPattern)
+        return fmt.Errorf("qualifier %q does not match pattern %s", qualifier, c.qualifierPattern)
+    }
+    return nil
+}
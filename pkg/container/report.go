@@ -0,0 +1,69 @@
+// pkg/container/report.go
+package container
+
+import (
+    "fmt"
+    "sort"
+    "strings"
+)
+
+// StartupReport returns a human-readable boot banner for logs summarising the wiring:
+// services per scope, registered aspects with their pointcuts, active profiles, lifecycle
+// hooks and validation warnings. Like Dump, its format is meant for people and may change.
+func (c *Container) StartupReport() string {
+    c.mu.RLock()
+    byScope := make(map[string]int)
+    for _, service := range c.services {
+        byScope[service.Scope.String()]++
+    }
+    total := len(c.services)
+    c.mu.RUnlock()
+
+    scopes := make([]string, 0, len(byScope))
+    for scope := range byScope {
+        scopes = append(scopes, scope)
+    }
+    sort.Strings(scopes)
+
+    var builder strings.Builder
+    builder.WriteString(fmt.Sprintf("Container %d startup report\n", c.id))
+
+    builder.WriteString(fmt.Sprintf("Services: %d\n", total))
+    for _, scope := range scopes {
+        builder.WriteString(fmt.Sprintf("  - %s: %d\n", scope, byScope[scope]))
+    }
+
+    aspects := c.AspectSummary()
+    builder.WriteString(fmt.Sprintf("Aspects: %d\n", len(aspects)))
+    for _, aspect := range aspects {
+        state := ""
+        if !aspect.Enabled {
+            state = " (disabled)"
+        }
+        builder.WriteString(fmt.Sprintf("  - %s %s on %q%s\n", aspect.Type, aspect.Kind, aspect.PointCut, state))
+    }
+
+    profiles := c.profileManager.Active()
+    if len(profiles) == 0 {
+        builder.WriteString("Active profiles: none\n")
+    } else {
+        builder.WriteString(fmt.Sprintf("Active profiles: %s\n", strings.Join(profiles, ", ")))
+    }
+
+    lm := c.lifecycleManager
+    lm.mu.Lock()
+    postConstructHooks, preDestroyHooks := len(lm.postConstructHooks), len(lm.preDestroyHooks)
+    lm.mu.Unlock()
+    builder.WriteString(fmt.Sprintf("Lifecycle hooks: %d post-construct, %d pre-destroy\n", postConstructHooks, preDestroyHooks))
+
+    if err := c.Validate(); err != nil {
+        warnings := strings.Split(err.Error(), "\n")
+        builder.WriteString(fmt.Sprintf("Validation warnings: %d\n", len(warnings)))
+        for _, warning := range warnings {
+            builder.WriteString(fmt.Sprintf("  - %s\n", warning))
+        }
+    } else {
+        builder.WriteString("Validation warnings: none\n")
+    }
+    return builder.String()
+}
@@ -0,0 +1,33 @@
+package container
+
+import (
+    "testing"
+
+    "di-extended/pkg/aop"
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+func TestContainer_StartupReport(t *testing.T) {
+    container := NewContainer()
+    require.NoError(t, container.Register("testService", &testServiceImpl{}, Singleton))
+    require.NoError(t, container.Register("prototypeService", &testServiceImpl{}, Prototype))
+    require.NoError(t, container.RegisterWithDeps("consumer", &TestStruct{}, Singleton, "missingService"))
+    container.AddAspect(&recordingAspect{kind: aop.Before, pointcut: ".*Service.*"})
+    container.SetActiveProfiles("production")
+    container.GetLifecycleManager().AddPostConstructHook(LifecycleHook{
+        Name:    "noop",
+        Handler: func(interface{}) error { return nil },
+    })
+
+    report := container.StartupReport()
+    assert.Contains(t, report, "Services: 3\n")
+    assert.Contains(t, report, "  - Singleton: 2\n")
+    assert.Contains(t, report, "  - Prototype: 1\n")
+    assert.Contains(t, report, "Aspects: 1\n")
+    assert.Contains(t, report, `*container.recordingAspect Before on ".*Service.*"`)
+    assert.Contains(t, report, "Active profiles: production\n")
+    assert.Contains(t, report, "Lifecycle hooks: 1 post-construct, 0 pre-destroy\n")
+    assert.Contains(t, report, "Validation warnings: 1\n")
+    assert.Contains(t, report, "service consumer depends on missing service missingService")
+}
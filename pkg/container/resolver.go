@@ -0,0 +1,57 @@
+// pkg/container/resolver.go
+package container
+
+import (
+    "fmt"
+    "reflect"
+)
+
+// Resolver is the lookup side of a container. Code that only needs to fetch services can
+// depend on Resolver instead of *Container and be tested with a hand-written mock.
+type Resolver interface {
+    Resolve(qualifier string) (interface{}, error)
+    Has(qualifier string) bool
+}
+
+var _ Resolver = (*Container)(nil)
+
+// Inject fills the di-tagged fields of target from r. When r is a *Container this is
+// InjectStruct with all of its features; any other Resolver gets the basic behaviour:
+// exported tagged fields are resolved by qualifier, required:"true" fields must resolve,
+// and resolved services must be assignable to their field.
+func Inject(r Resolver, target interface{}) error {
+    if c, ok := r.(*Container); ok {
+        return c.InjectStruct(target)
+    }
+
+    targetValue := reflect.ValueOf(target)
+    if targetValue.Kind() != reflect.Ptr || targetValue.Elem().Kind() != reflect.Struct {
+        return fmt.Errorf("target must be a pointer to struct, got: %T", target)
+    }
+    targetValue = targetValue.Elem()
+
+    for _, field := range injectionPlanFor(targetValue.Type()) {
+        if !field.tagged || !field.exported {
+            continue
+        }
+        fieldValue := targetValue.Field(field.index)
+
+        if !r.Has(field.qualifier) {
+            if field.required {
+                return fmt.Errorf("required service not found for field %s: %s", field.name, field.qualifier)
+            }
+            continue
+        }
+        service, err := r.Resolve(field.qualifier)
+        if err != nil {
+            return fmt.Errorf("failed to resolve %s for field %s: %w", field.qualifier, field.name, err)
+        }
+
+        serviceValue := reflect.ValueOf(service)
+        if !serviceValue.IsValid() || !serviceValue.Type().AssignableTo(fieldValue.Type()) {
+            return fmt.Errorf("service type %T is not assignable to field type %v", service, fieldValue.Type())
+        }
+        fieldValue.Set(serviceValue)
+    }
+    return nil
+}
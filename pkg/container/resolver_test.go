@@ -0,0 +1,57 @@
+package container
+
+import (
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+// mockResolver serves services from a map and records the qualifiers it was asked for
+type mockResolver struct {
+    services map[string]interface{}
+    resolved []string
+}
+
+func (m *mockResolver) Resolve(qualifier string) (interface{}, error) {
+    m.resolved = append(m.resolved, qualifier)
+    return m.services[qualifier], nil
+}
+
+func (m *mockResolver) Has(qualifier string) bool {
+    _, ok := m.services[qualifier]
+    return ok
+}
+
+func TestInject_MockResolver(t *testing.T) {
+    mock := &testServiceImpl{name: "mock"}
+    resolver := &mockResolver{services: map[string]interface{}{"testService": mock}}
+
+    target := &TestStruct{}
+    require.NoError(t, Inject(resolver, target))
+    assert.Same(t, mock, target.Service)
+    assert.Nil(t, target.Optional)
+    assert.Equal(t, []string{"testService"}, resolver.resolved)
+
+    // Required fields must be provided by the resolver
+    err := Inject(&mockResolver{services: map[string]interface{}{}}, &requiringStruct{})
+    assert.ErrorContains(t, err, "required service not found")
+
+    resolver.services["testService"] = "not a service"
+    assert.ErrorContains(t, Inject(resolver, &TestStruct{}), "is not assignable")
+    assert.Error(t, Inject(resolver, TestStruct{}))
+}
+
+func TestInject_Container(t *testing.T) {
+    container := NewContainer()
+    service := &testServiceImpl{name: "real"}
+    require.NoError(t, container.Register("testService", service, Singleton))
+
+    target := &TestStruct{}
+    require.NoError(t, Inject(container, target))
+    assert.Same(t, service, target.Service)
+
+    resolved, err := ResolveMany(&mockResolver{services: map[string]interface{}{"a": service}}, "a")
+    require.NoError(t, err)
+    assert.Same(t, service, resolved["a"])
+}
@@ -1,6 +1,13 @@
 // pkg/container/scope.go
 package container
 
+import (
+    "fmt"
+    "reflect"
+    "strings"
+    "sync"
+)
+
 type Scope int
 
 const (
@@ -8,11 +15,108 @@ const (
     Prototype
     Request
     Session
+    WeakSingleton // Cached like a singleton but can be evicted and rebuilt on the next resolve
 )
 
+// caches reports whether instances of the scope are cached by the container
+func (s Scope) caches() bool {
+    return s == Singleton || s == WeakSingleton
+}
+
+// String returns the scope name, e.g. "Singleton"
+func (s Scope) String() string {
+    switch s {
+    case Singleton:
+        return "Singleton"
+    case Prototype:
+        return "Prototype"
+    case Request:
+        return "Request"
+    case Session:
+        return "Session"
+    case WeakSingleton:
+        return "WeakSingleton"
+    default:
+        if name, ok := customScopeName(s); ok {
+            return name
+        }
+        return fmt.Sprintf("Scope(%d)", int(s))
+    }
+}
+
+// ScopeConstraint can be implemented by services that are only valid in some scopes,
+// e.g. a stateful service that must never be registered as a prototype
+type ScopeConstraint interface {
+    AllowedScopes() []Scope
+}
+
+// allowsScope reports whether service accepts being registered with scope
+func allowsScope(service interface{}, scope Scope) bool {
+    constrained, ok := service.(ScopeConstraint)
+    if !ok {
+        return true
+    }
+    for _, allowed := range constrained.AllowedScopes() {
+        if allowed == scope {
+            return true
+        }
+    }
+    return false
+}
+
 type ScopedService struct {
     Instance     interface{}
     Scope        Scope
+    Type         reflect.Type // Concrete type of the registered service, used for type-based resolution
     Factory      func() interface{}
     Dependencies []string // For prototype scope dependency tracking
-}
\ No newline at end of file
+    Primary      bool     // Preferred candidate when several services match a type
+    Condition    Condition // Optional activation condition, evaluated on every resolve
+    Metadata     map[string]string // Arbitrary labels attached at registration, queried by FindByMeta
+    Groups       []string  // Named groups the service belongs to, queried by FindByGroup
+    lazy         bool      // Singleton instance is built by Factory on first resolve
+    external     bool      // Registered by RegisterExternal or shared by Import; never run through the lifecycle
+    shared       bool      // Factory hands out the registered instance itself instead of building a new one
+    construct    func() (interface{}, error) // Error-returning factory, used instead of Factory when set
+    parameterized func(args ...interface{}) (interface{}, error) // Factory of a parameterized prototype
+    order        uint64    // Registration sequence number within the container
+    activation   *sync.Mutex // Serializes lazy builds of this service, see activate; set by store
+    decorators   []func(interface{}) interface{} // Applied to every new instance after its lifecycle, see Decorate
+    undecorated  interface{} // Instance before decoration, which PreDestroy runs on
+    profiles     []string    // Active profiles when the cached instance was built or last refreshed, see ResolveWithProfiles
+}
+
+// create builds a new instance with construct when set, otherwise with Factory
+func (s *ScopedService) create() (interface{}, error) {
+    if s.construct != nil {
+        return s.construct()
+    }
+    return s.Factory(), nil
+}
+
+// destroyable returns the cached instance the lifecycle ran on, which is the one to
+// pre-destroy: the undecorated instance of a decorated service, otherwise Instance
+func (s *ScopedService) destroyable() interface{} {
+    if s.undecorated != nil {
+        return s.undecorated
+    }
+    return s.Instance
+}
+
+// String summarizes the scoped service for debugging
+func (s *ScopedService) String() string {
+    typeName := "<unknown>"
+    if s.Instance != nil {
+        typeName = reflect.TypeOf(s.Instance).String()
+    } else if s.Type != nil {
+        typeName = s.Type.String()
+    }
+
+    return fmt.Sprintf("scope=%s type=%s instance=%t factory=%t primary=%t dependencies=[%s]",
+        s.Scope,
+        typeName,
+        s.Instance != nil,
+        s.Factory != nil,
+        s.Primary,
+        strings.Join(s.Dependencies, ", "))
+}
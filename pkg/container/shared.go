@@ -0,0 +1,43 @@
+// pkg/container/shared.go
+package container
+
+import (
+    "fmt"
+)
+
+// RegisterShared registers a service on the root of this container's hierarchy, making it
+// visible to every container below the root, e.g. to sibling modules that share a parent.
+// Children resolve it through the usual lookup in their ancestors. On a container without a
+// parent it is the same as Register.
+func (c *Container) RegisterShared(qualifier string, service interface{}, scope Scope) error {
+    root, err := c.root()
+    if err != nil {
+        return err
+    }
+    c.log.Infow("Registering shared service on root container",
+        "qualifier", qualifier,
+        "container", c.id,
+        "root", root.id)
+    return root.Register(qualifier, service, scope)
+}
+
+// root returns the topmost ancestor of the container, or the container itself
+func (c *Container) root() (*Container, error) {
+    visited := map[*Container]bool{c: true}
+    current := c
+    for {
+        current.mu.RLock()
+        parent := current.parent
+        current.mu.RUnlock()
+
+        if parent == nil {
+            return current, nil
+        }
+        if visited[parent] {
+            c.log.Errorw("Container hierarchy has a cycle", "container", c.id, "parent", parent.id)
+            return nil, fmt.Errorf("container hierarchy of container %d has a cycle at container %d", c.id, parent.id)
+        }
+        visited[parent] = true
+        current = parent
+    }
+}
@@ -0,0 +1,46 @@
+package container
+
+import (
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+func TestContainer_RegisterShared(t *testing.T) {
+    root := NewContainer()
+    billing := NewContainer()
+    billing.SetParent(root)
+    shipping := NewContainer()
+    shipping.SetParent(root)
+    invoices := NewContainer()
+    invoices.SetParent(billing)
+
+    shared := &testServiceImpl{}
+    require.NoError(t, invoices.RegisterShared("clock", shared, Singleton))
+
+    assert.True(t, root.Has("clock"))
+    _, registeredLocally := invoices.InstanceID("clock")
+    assert.False(t, registeredLocally, "the service lives on the root")
+    for _, child := range []*Container{billing, shipping, invoices} {
+        resolved, err := child.Resolve("clock")
+        require.NoError(t, err)
+        assert.Same(t, shared, resolved)
+    }
+
+    // A service registered in a sibling directly stays private to it
+    require.NoError(t, billing.Register("ledger", &TestStruct{}, Singleton))
+    _, err := shipping.Resolve("ledger")
+    assert.Error(t, err)
+
+    assert.ErrorContains(t, shipping.RegisterShared("clock", &testServiceImpl{}, Singleton), "already registered")
+}
+
+func TestContainer_RegisterSharedCycle(t *testing.T) {
+    first := NewContainer()
+    second := NewContainer()
+    first.SetParent(second)
+    second.SetParent(first)
+
+    assert.ErrorContains(t, first.RegisterShared("clock", &testServiceImpl{}, Singleton), "has a cycle")
+}
@@ -0,0 +1,65 @@
+// pkg/container/tracing.go
+package container
+
+import (
+    "context"
+    "fmt"
+
+    "di-extended/pkg/aop"
+)
+
+// Tracer starts spans for container operations. It mirrors the shape of an OpenTelemetry
+// trace.Tracer without depending on it; wrap an otel tracer in a small adapter to use one.
+type Tracer interface {
+    Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is a single traced operation started by a Tracer
+type Span interface {
+    RecordError(err error)
+    End()
+}
+
+// SetTracer configures the tracer used for spans around Resolve and aspect advice.
+// Passing nil disables tracing.
+// It panics on a frozen container.
+func (c *Container) SetTracer(tracer Tracer) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    if err := c.checkMutable("set", "tracer"); err != nil {
+        panic(err)
+    }
+    c.tracer = tracer
+}
+
+// startSpan starts a span when a tracer is configured. The returned function ends the span,
+// recording err first when it is non-nil; without a tracer both are no-ops.
+// A nil ctx starts a root span.
+func (c *Container) startSpan(ctx context.Context, name string) (context.Context, func(err error)) {
+    c.mu.RLock()
+    tracer := c.tracer
+    c.mu.RUnlock()
+
+    if tracer == nil {
+        return ctx, func(error) {}
+    }
+    if ctx == nil {
+        ctx = context.Background()
+    }
+
+    ctx, span := tracer.Start(ctx, name)
+    return ctx, func(err error) {
+        if err != nil {
+            span.RecordError(err)
+        }
+        span.End()
+    }
+}
+
+// tracedAdvice runs an aspect's advice inside a span named after the aspect and join point
+func (c *Container) tracedAdvice(aspect aop.Aspect, jp *aop.JoinPoint) error {
+    _, end := c.startSpan(jp.Ctx, fmt.Sprintf("di.aspect %T %s", aop.Underlying(aspect), jp.Signature()))
+    err := aspect.Advice(jp)
+    end(err)
+    return err
+}
@@ -0,0 +1,76 @@
+package container
+
+import (
+    "context"
+    "errors"
+    "sync"
+    "testing"
+
+    "di-extended/pkg/aop"
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+type fakeSpan struct {
+    name  string
+    ended bool
+    err   error
+}
+
+func (s *fakeSpan) RecordError(err error) { s.err = err }
+func (s *fakeSpan) End()                  { s.ended = true }
+
+type fakeTracer struct {
+    mu    sync.Mutex
+    spans []*fakeSpan
+}
+
+func (t *fakeTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+    t.mu.Lock()
+    defer t.mu.Unlock()
+    span := &fakeSpan{name: name}
+    t.spans = append(t.spans, span)
+    return ctx, span
+}
+
+func TestContainer_SetTracer(t *testing.T) {
+    container := NewContainer()
+    tracer := &fakeTracer{}
+    container.SetTracer(tracer)
+    require.NoError(t, container.Register("testService", &testServiceImpl{}, Singleton))
+
+    _, err := container.Resolve("testService")
+    require.NoError(t, err)
+    require.Len(t, tracer.spans, 1)
+    assert.Equal(t, "di.resolve testService", tracer.spans[0].name)
+    assert.True(t, tracer.spans[0].ended)
+    assert.NoError(t, tracer.spans[0].err)
+
+    // Failures are recorded on the span
+    _, err = container.Resolve("missing")
+    require.Error(t, err)
+    require.Len(t, tracer.spans, 2)
+    assert.True(t, tracer.spans[1].ended)
+    assert.Equal(t, err, tracer.spans[1].err)
+
+    // Disabling the tracer stops new spans
+    container.SetTracer(nil)
+    _, _ = container.Resolve("testService")
+    assert.Len(t, tracer.spans, 2)
+}
+
+func TestContainer_SetTracerAspects(t *testing.T) {
+    container := NewContainer()
+    tracer := &fakeTracer{}
+    container.SetTracer(tracer)
+    container.AddAspect(&recordingAspect{kind: aop.Before, pointcut: ".*", err: errors.New("denied")})
+
+    jp, err := aop.NewJoinPoint(&tracedService{}, "Handle", context.Background(), "x")
+    require.NoError(t, err)
+    require.Error(t, container.ExecuteAspects(jp))
+
+    require.Len(t, tracer.spans, 1)
+    assert.Equal(t, "di.aspect *container.recordingAspect tracedService.Handle", tracer.spans[0].name)
+    assert.True(t, tracer.spans[0].ended)
+    assert.EqualError(t, tracer.spans[0].err, "denied")
+}
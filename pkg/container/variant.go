@@ -0,0 +1,64 @@
+// pkg/container/variant.go
+package container
+
+import (
+    "fmt"
+    "strings"
+)
+
+// RegisterProfileVariant registers service as the variant of qualifier for profile. Resolving
+// qualifier, including through di tags, yields the variant of the active profile, which works
+// for value types as well as interfaces, e.g. a Config struct per environment:
+//
+//     c.RegisterProfileVariant("config", "dev", Config{Debug: true}, container.Singleton)
+//     c.RegisterProfileVariant("config", "prod", Config{Debug: false}, container.Singleton)
+//
+// Each variant is stored as a conditional service under "<qualifier>.<profile>", so it is only
+// built while its profile is active. A plain registration of qualifier takes precedence over its
+// variants, and resolving is an error when several active profiles have a variant.
+func (c *Container) RegisterProfileVariant(qualifier, profile string, service interface{}, scope Scope) error {
+    if strings.TrimSpace(profile) == "" {
+        c.log.Errorw("Cannot register variant without profile", "qualifier", qualifier)
+        return fmt.Errorf("cannot register variant of %s for a blank profile", qualifier)
+    }
+
+    return c.register(variantQualifier(qualifier, profile), service, scope, func(s *ScopedService) {
+        s.Condition = &ProfileCondition{ProfileName: profile}
+        s.lazy = true
+        // Called with the lock held, after every check that can reject the registration
+        if c.profileVariants == nil {
+            c.profileVariants = make(map[string][]string)
+        }
+        c.profileVariants[qualifier] = append(c.profileVariants[qualifier], profile)
+        c.log.Infow("Registered profile variant", "qualifier", qualifier, "profile", profile)
+    })
+}
+
+// variantQualifier returns the qualifier a profile variant is stored under
+func variantQualifier(qualifier, profile string) string {
+    return qualifier + "." + profile
+}
+
+// activeVariant returns the qualifier of the variant of qualifier for the active profile.
+// ok is false when qualifier has no variant in this container or none of them is active.
+func (c *Container) activeVariant(qualifier string) (variant string, ok bool, err error) {
+    c.mu.RLock()
+    profiles := c.profileVariants[qualifier]
+    c.mu.RUnlock()
+
+    active := make([]string, 0, 1)
+    for _, profile := range profiles {
+        if c.IsProfileActive(profile) {
+            active = append(active, profile)
+        }
+    }
+    switch len(active) {
+    case 0:
+        return "", false, nil
+    case 1:
+        return variantQualifier(qualifier, active[0]), true, nil
+    default:
+        c.log.Errorw("Several profile variants are active", "qualifier", qualifier, "profiles", active)
+        return "", false, fmt.Errorf("service %s has variants for several active profiles: %v", qualifier, active)
+    }
+}
@@ -0,0 +1,76 @@
+package container
+
+import (
+    "reflect"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+type appConfig struct {
+    DatabaseURL string
+    Debug       bool
+}
+
+type configuredApp struct {
+    Config appConfig `di:"config" required:"true"`
+}
+
+func TestContainer_RegisterProfileVariant(t *testing.T) {
+    container := NewContainer()
+    dev := appConfig{DatabaseURL: "postgres://localhost/app", Debug: true}
+    prod := appConfig{DatabaseURL: "postgres://db.internal/app"}
+    require.NoError(t, container.RegisterProfileVariant("config", "dev", dev, Singleton))
+    require.NoError(t, container.RegisterProfileVariant("config", "prod", prod, Singleton))
+    assert.True(t, container.Has("config"))
+
+    container.SetActiveProfiles("prod")
+    app := &configuredApp{}
+    require.NoError(t, container.InjectStruct(app))
+    assert.Equal(t, prod, app.Config)
+
+    container.SetActiveProfiles("dev")
+    require.NoError(t, container.InjectStruct(app))
+    assert.Equal(t, dev, app.Config)
+
+    plan, err := container.PlanInjection(&configuredApp{})
+    require.NoError(t, err)
+    assert.Equal(t, reflect.TypeOf(appConfig{}), plan.Fields[0].ServiceType)
+    assert.Empty(t, plan.Problems())
+
+    // Each variant can still be resolved under its own qualifier while its profile is active
+    resolved, err := container.Resolve("config.dev")
+    require.NoError(t, err)
+    assert.Equal(t, dev, resolved)
+}
+
+func TestContainer_RegisterProfileVariantResolution(t *testing.T) {
+    container := NewContainer()
+    require.NoError(t, container.RegisterProfileVariant("config", "dev", appConfig{Debug: true}, Singleton))
+    require.NoError(t, container.RegisterProfileVariant("config", "prod", appConfig{}, Singleton))
+
+    _, err := container.Resolve("config")
+    assert.EqualError(t, err, "no service found for qualifier: config")
+
+    container.SetActiveProfiles("dev", "prod")
+    _, err = container.Resolve("config")
+    assert.EqualError(t, err, "service config has variants for several active profiles: [dev prod]")
+
+    // A child sees the variants of its parent
+    child := NewContainer()
+    child.SetParent(container)
+    container.SetActiveProfiles("prod")
+    resolved, err := child.Resolve("config")
+    require.NoError(t, err)
+    assert.Equal(t, appConfig{}, resolved)
+
+    // A plain registration takes precedence
+    require.NoError(t, container.Register("config", appConfig{DatabaseURL: "override"}, Singleton))
+    resolved, err = container.Resolve("config")
+    require.NoError(t, err)
+    assert.Equal(t, appConfig{DatabaseURL: "override"}, resolved)
+
+    assert.ErrorContains(t, container.RegisterProfileVariant("config", " ", appConfig{}, Singleton), "blank profile")
+    assert.ErrorContains(t, container.RegisterProfileVariant("config", "dev", appConfig{}, Singleton), "already registered")
+}
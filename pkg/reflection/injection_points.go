@@ -0,0 +1,48 @@
+package reflection
+
+import (
+    "reflect"
+)
+
+// InjectionPoint describes a di-tagged field of a struct type
+type InjectionPoint struct {
+    Field     string       // Field name
+    Type      reflect.Type // Declared field type
+    Qualifier string       // Value of the di tag
+    Required  bool         // Whether the field is tagged required:"true"
+    Group     string       // Value of the group tag, if any
+    Exported  bool         // Whether the container can set the field
+}
+
+// ExtractInjectionPoints lists the di-tagged fields of a struct or pointer-to-struct type
+// in declaration order. It works on types alone, so tooling can report on wiring without
+// constructing any instances. Other kinds of types have no injection points.
+func ExtractInjectionPoints(t reflect.Type) []InjectionPoint {
+    points := make([]InjectionPoint, 0)
+    if t == nil {
+        return points
+    }
+    for t.Kind() == reflect.Ptr {
+        t = t.Elem()
+    }
+    if t.Kind() != reflect.Struct {
+        return points
+    }
+
+    for i := 0; i < t.NumField(); i++ {
+        field := t.Field(i)
+        qualifier, tagged := field.Tag.Lookup("di")
+        if !tagged {
+            continue
+        }
+        points = append(points, InjectionPoint{
+            Field:     field.Name,
+            Type:      field.Type,
+            Qualifier: qualifier,
+            Required:  field.Tag.Get("required") == "true",
+            Group:     field.Tag.Get("group"),
+            Exported:  field.PkgPath == "",
+        })
+    }
+    return points
+}
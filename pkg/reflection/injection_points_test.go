@@ -0,0 +1,55 @@
+package reflection
+
+import (
+    "reflect"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+type paymentProcessor interface {
+    ProcessPayment(amount float64) error
+}
+
+type inventoryService interface {
+    CheckStock(itemID string) (int, error)
+}
+
+type notificationService interface {
+    NotifyUser(userID, message string) error
+}
+
+type orderServiceImpl struct {
+    PaymentProcessor paymentProcessor    `di:"paymentService" required:"true"`
+    Inventory        inventoryService    `di:"inventoryService" required:"true"`
+    Notifications    notificationService `di:"notificationService" group:"notifiers"`
+    createdOrders    int
+}
+
+func TestExtractInjectionPoints(t *testing.T) {
+    points := ExtractInjectionPoints(reflect.TypeOf(&orderServiceImpl{}))
+    require.Len(t, points, 3)
+
+    expected := []struct {
+        field     string
+        qualifier string
+        required  bool
+        group     string
+    }{
+        {field: "PaymentProcessor", qualifier: "paymentService", required: true},
+        {field: "Inventory", qualifier: "inventoryService", required: true},
+        {field: "Notifications", qualifier: "notificationService", required: false, group: "notifiers"},
+    }
+    for i, want := range expected {
+        assert.Equal(t, want.field, points[i].Field)
+        assert.Equal(t, want.qualifier, points[i].Qualifier)
+        assert.Equal(t, want.required, points[i].Required)
+        assert.Equal(t, want.group, points[i].Group)
+        assert.True(t, points[i].Exported)
+    }
+    assert.Equal(t, reflect.TypeOf((*paymentProcessor)(nil)).Elem(), points[0].Type)
+
+    assert.Empty(t, ExtractInjectionPoints(reflect.TypeOf(42)))
+    assert.Empty(t, ExtractInjectionPoints(nil))
+}
@@ -4,6 +4,7 @@ import (
     "fmt"
     "reflect"
     "strings"
+    "unsafe"
     "di-extended/pkg/logger"
     "di-extended/pkg/container"
     "di-extended/pkg/aop"
@@ -17,11 +18,15 @@ type StructInfo struct {
     Scope           container.Scope
     ActiveProfiles  []string
     AspectInfo      *AspectInfo
+    Warnings        []string // Contradictory or unusable injection configuration
 }
 
 type FieldInfo struct {
     Name          string
     Type          string
+    Kind          reflect.Kind
+    ChanDir       reflect.ChanDir // Direction of channel fields, zero otherwise
+    FuncSignature string          // Signature of func fields, e.g. "func(int) error"
     Tags          map[string]string
     Value         interface{}
     IsExported    bool
@@ -37,7 +42,9 @@ type AspectInfo struct {
 }
 
 type Inspector struct {
-    log *zap.SugaredLogger
+    log          *zap.SugaredLogger
+    unsafeValues bool // Read unexported field values through unsafe, see WithUnsafeValues
+    formatValue  func(field FieldInfo) string // Renders field values in PrettyPrint, see SetValueFormatter
 }
 
 func NewInspector() *Inspector {
@@ -46,6 +53,25 @@ func NewInspector() *Inspector {
     }
 }
 
+// WithUnsafeValues makes the inspector read the values of unexported fields too.
+// This bypasses Go's visibility rules through package unsafe and is meant for debugging
+// only: the values returned alias the target's private state and must not be modified.
+func (i *Inspector) WithUnsafeValues(enabled bool) *Inspector {
+    i.unsafeValues = enabled
+    return i
+}
+
+// SetValueFormatter replaces the %v rendering of field values in PrettyPrint, e.g. to redact
+// secret-looking fields or truncate large values. A nil formatter restores the default.
+// Unlike the default, which only prints exported values, the formatter is called for every
+// field, unexported ones included, so it can mask a field such as apiKey by name. An
+// unexported field has a nil Value unless WithUnsafeValues is enabled; returning "" omits
+// the Value line for a field.
+func (i *Inspector) SetValueFormatter(format func(field FieldInfo) string) *Inspector {
+    i.formatValue = format
+    return i
+}
+
 func (i *Inspector) InspectStruct(target interface{}) (*StructInfo, error) {
     i.log.Info("Starting struct inspection")
 
@@ -60,6 +86,9 @@ func (i *Inspector) InspectStruct(target interface{}) (*StructInfo, error) {
     i.log.Debugw("Analyzing target type",
         "initialType", targetType.String())
 
+    // Keep an addressable instance so convention methods with pointer receivers can be called
+    instance := targetValue
+
     // Handle pointer types
     if targetType.Kind() == reflect.Ptr {
         i.log.Debug("Target is a pointer, dereferencing")
@@ -79,13 +108,21 @@ func (i *Inspector) InspectStruct(target interface{}) (*StructInfo, error) {
             targetType.Kind())
     }
 
+    if instance.Kind() != reflect.Ptr {
+        addressable := reflect.New(targetType)
+        addressable.Elem().Set(targetValue)
+        instance = addressable
+    }
+    targetValue = instance.Elem() // Addressable, so unexported fields can be read when enabled
+
     info := &StructInfo{
         Name:           targetType.Name(),
         Fields:         make([]FieldInfo, 0, targetType.NumField()),
         HasLifecycle:   i.implementsLifecycle(targetType),
-        Scope:          i.determineScope(targetType),
-        ActiveProfiles: i.getActiveProfiles(targetType),
+        Scope:          i.determineScope(instance, targetType),
+        ActiveProfiles: i.getActiveProfiles(instance, targetType),
         AspectInfo:     i.inspectAspects(targetType),
+        Warnings:       make([]string, 0),
     }
 
     // Analyze each field
@@ -95,6 +132,7 @@ func (i *Inspector) InspectStruct(target interface{}) (*StructInfo, error) {
 
         fieldInfo := i.inspectField(field, fieldValue)
         info.Fields = append(info.Fields, fieldInfo)
+        info.Warnings = append(info.Warnings, i.fieldWarnings(fieldInfo)...)
     }
 
     i.log.Info("Completed struct inspection")
@@ -114,6 +152,10 @@ func (i *Inspector) inspectField(field reflect.StructField, fieldValue reflect.V
         i.log.Debugw("Retrieved field value",
             "fieldName", field.Name,
             "canInterface", true)
+    } else if i.unsafeValues && fieldValue.CanAddr() {
+        value = reflect.NewAt(fieldValue.Type(), unsafe.Pointer(fieldValue.UnsafeAddr())).Elem().Interface()
+        i.log.Debugw("Retrieved unexported field value through unsafe",
+            "fieldName", field.Name)
     } else {
         i.log.Debugw("Cannot interface field value",
             "fieldName", field.Name,
@@ -125,9 +167,21 @@ func (i *Inspector) inspectField(field reflect.StructField, fieldValue reflect.V
     injectionType := tags["inject"]
     defaultValue := tags["default"]
 
+    var chanDir reflect.ChanDir
+    var funcSignature string
+    switch field.Type.Kind() {
+    case reflect.Chan:
+        chanDir = field.Type.ChanDir()
+    case reflect.Func:
+        funcSignature = field.Type.String()
+    }
+
     return FieldInfo{
         Name:          field.Name,
         Type:          field.Type.String(),
+        Kind:          field.Type.Kind(),
+        ChanDir:       chanDir,
+        FuncSignature: funcSignature,
         Tags:          tags,
         Value:         value,
         IsExported:    isExported,
@@ -137,6 +191,28 @@ func (i *Inspector) inspectField(field reflect.StructField, fieldValue reflect.V
     }
 }
 
+// fieldWarnings flags injection configuration that cannot work as written
+func (i *Inspector) fieldWarnings(field FieldInfo) []string {
+    warnings := make([]string, 0)
+    if !field.IsRequired {
+        return warnings
+    }
+
+    if field.DefaultValue != "" {
+        warnings = append(warnings, fmt.Sprintf(
+            "field %s is required but also declares default %q", field.Name, field.DefaultValue))
+    }
+    if !field.IsExported {
+        warnings = append(warnings, fmt.Sprintf(
+            "field %s is required but unexported, so it can never be injected", field.Name))
+    }
+
+    for _, warning := range warnings {
+        i.log.Warnw("Injection configuration warning", "fieldName", field.Name, "warning", warning)
+    }
+    return warnings
+}
+
 func (i *Inspector) parseTags(field reflect.StructField) map[string]string {
     tags := make(map[string]string)
     if field.Tag != "" {
@@ -161,26 +237,60 @@ func (i *Inspector) implementsLifecycle(t reflect.Type) bool {
     return t.Implements(lifecycleType) || reflect.PointerTo(t).Implements(lifecycleType)
 }
 
-func (i *Inspector) determineScope(t reflect.Type) container.Scope {
-    if scope, ok := t.MethodByName("Scope"); ok {
-        if scope.Type.NumOut() == 1 && scope.Type.Out(0) == reflect.TypeOf(container.Scope(0)) {
-            return container.Singleton // Default to singleton if scope is defined
-        }
+// determineScope reads the scope declared by a Scope() container.Scope method.
+// With an instance the method is called; for a type-only inspection (invalid instance)
+// a declared Scope method falls back to Singleton. Without the method the scope is Prototype.
+func (i *Inspector) determineScope(instance reflect.Value, t reflect.Type) container.Scope {
+    scopeType := reflect.TypeOf(container.Scope(0))
+    method, ok := reflect.PointerTo(t).MethodByName("Scope")
+    if !ok || method.Type.NumIn() != 1 || method.Type.NumOut() != 1 || method.Type.Out(0) != scopeType {
+        return container.Prototype // Default to prototype if no scope is specified
+    }
+
+    if result, ok := i.callConvention(instance, "Scope"); ok {
+        return result.Interface().(container.Scope)
     }
-    return container.Prototype // Default to prototype if no scope is specified
+    return container.Singleton // Default to singleton if scope is defined but cannot be called
 }
 
-func (i *Inspector) getActiveProfiles(t reflect.Type) []string {
+// getActiveProfiles reads the profiles declared by a Profiles() []string method.
+// Type-only inspections, or types without the method, report no profiles.
+func (i *Inspector) getActiveProfiles(instance reflect.Value, t reflect.Type) []string {
     profiles := make([]string, 0)
-    if profile, ok := t.MethodByName("Profiles"); ok {
-        if profile.Type.NumOut() == 1 && profile.Type.Out(0).Kind() == reflect.Slice {
-            // Could extract profile information if available
-            // This would need to be called on an instance
-        }
+    method, ok := reflect.PointerTo(t).MethodByName("Profiles")
+    if !ok || method.Type.NumIn() != 1 || method.Type.NumOut() != 1 ||
+        method.Type.Out(0) != reflect.TypeOf([]string(nil)) {
+        return profiles
+    }
+
+    if result, ok := i.callConvention(instance, "Profiles"); ok {
+        profiles = append(profiles, result.Interface().([]string)...)
     }
     return profiles
 }
 
+// callConvention calls a no-argument, single-result method on instance, recovering from panics.
+// It reports false when there is no usable instance or the call panicked.
+func (i *Inspector) callConvention(instance reflect.Value, name string) (result reflect.Value, ok bool) {
+    if !instance.IsValid() || (instance.Kind() == reflect.Ptr && instance.IsNil()) {
+        i.log.Debugw("No instance available for convention method", "method", name)
+        return reflect.Value{}, false
+    }
+
+    method := instance.MethodByName(name)
+    if !method.IsValid() {
+        return reflect.Value{}, false
+    }
+
+    defer func() {
+        if r := recover(); r != nil {
+            i.log.Warnw("Convention method panicked", "method", name, "panic", r)
+            result, ok = reflect.Value{}, false
+        }
+    }()
+    return method.Call(nil)[0], true
+}
+
 func (i *Inspector) inspectAspects(t reflect.Type) *AspectInfo {
     aspectInfo := &AspectInfo{
         HasAspects: false,
@@ -194,7 +304,7 @@ func (i *Inspector) inspectAspects(t reflect.Type) *AspectInfo {
         // Extract pointcuts and advices if the type implements Aspect
         if aspect, ok := reflect.New(t).Interface().(aop.Aspect); ok {
             aspectInfo.PointCuts = append(aspectInfo.PointCuts, aspect.PointCut())
-            aspectInfo.Advices = append(aspectInfo.Advices, fmt.Sprintf("%v", aspect.Kind()))
+            aspectInfo.Advices = append(aspectInfo.Advices, aspect.Kind().String())
         }
     }
 
@@ -227,12 +337,26 @@ func (i *Inspector) PrettyPrint(info *StructInfo) string {
         }
     }
 
+    if len(info.Warnings) > 0 {
+        builder.WriteString("Warnings:\n")
+        for _, warning := range info.Warnings {
+            builder.WriteString(fmt.Sprintf("  - %s\n", warning))
+        }
+    }
+
     builder.WriteString("Fields:\n")
     for _, field := range info.Fields {
         i.log.Debugw("Pretty printing field", "fieldName", field.Name)
 
         builder.WriteString(fmt.Sprintf("  - %s:\n", field.Name))
         builder.WriteString(fmt.Sprintf("    Type: %s\n", field.Type))
+        builder.WriteString(fmt.Sprintf("    Kind: %s\n", field.Kind))
+        if field.ChanDir != 0 {
+            builder.WriteString(fmt.Sprintf("    Channel Direction: %s\n", field.ChanDir))
+        }
+        if field.FuncSignature != "" {
+            builder.WriteString(fmt.Sprintf("    Signature: %s\n", field.FuncSignature))
+        }
         builder.WriteString(fmt.Sprintf("    Exported: %v\n", field.IsExported))
         builder.WriteString(fmt.Sprintf("    Required: %v\n", field.IsRequired))
 
@@ -251,10 +375,23 @@ func (i *Inspector) PrettyPrint(info *StructInfo) string {
             }
         }
 
-        if field.IsExported && field.Value != nil {
-            builder.WriteString(fmt.Sprintf("    Value: %v\n", field.Value))
+        if value, ok := i.formattedValue(field); ok {
+            builder.WriteString(fmt.Sprintf("    Value: %s\n", value))
         }
     }
 
     return builder.String()
-}
\ No newline at end of file
+}
+
+// formattedValue renders a field value for PrettyPrint and reports whether it should be printed:
+// with a custom formatter every field it renders non-empty, otherwise exported non-nil values
+func (i *Inspector) formattedValue(field FieldInfo) (string, bool) {
+    if i.formatValue != nil {
+        value := i.formatValue(field)
+        return value, value != ""
+    }
+    if !field.IsExported || field.Value == nil {
+        return "", false
+    }
+    return fmt.Sprintf("%v", field.Value), true
+}
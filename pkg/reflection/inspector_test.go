@@ -1,7 +1,14 @@
 package reflection
 
 import (
+    "fmt"
+    "io"
+    "reflect"
+    "strings"
     "testing"
+
+    "di-extended/pkg/aop"
+    "di-extended/pkg/container"
     "github.com/stretchr/testify/assert"
     "github.com/stretchr/testify/require"
 )
@@ -114,6 +121,76 @@ func TestInspector_PrettyPrint(t *testing.T) {
     assert.Contains(t, output, "3.14")
 }
 
+func TestInspector_SetValueFormatter(t *testing.T) {
+    type credentials struct {
+        User   string
+        ApiKey string
+    }
+
+    inspector := NewInspector().SetValueFormatter(func(field FieldInfo) string {
+        if strings.EqualFold(field.Name, "apiKey") {
+            return "****"
+        }
+        return fmt.Sprintf("%v", field.Value)
+    })
+
+    info, err := inspector.InspectStruct(credentials{User: "alice", ApiKey: "sk-live-123"})
+    require.NoError(t, err)
+
+    output := inspector.PrettyPrint(info)
+    assert.Contains(t, output, "Value: alice")
+    assert.Contains(t, output, "Value: ****")
+    assert.NotContains(t, output, "sk-live-123")
+
+    // Clearing the formatter restores the default rendering
+    assert.Contains(t, inspector.SetValueFormatter(nil).PrettyPrint(info), "Value: sk-live-123")
+}
+
+func TestInspector_SetValueFormatterUnexported(t *testing.T) {
+    type client struct {
+        Endpoint string
+        apiKey   string
+    }
+    target := client{Endpoint: "https://api.example.com", apiKey: "sk-live-123"}
+
+    redact := func(field FieldInfo) string {
+        if field.Name == "apiKey" {
+            return "****"
+        }
+        if field.Value == nil {
+            return ""
+        }
+        return fmt.Sprintf("%v", field.Value)
+    }
+
+    // The formatter sees unexported fields, whose values are only read with unsafe values
+    inspector := NewInspector().WithUnsafeValues(true).SetValueFormatter(redact)
+    info, err := inspector.InspectStruct(target)
+    require.NoError(t, err)
+    output := inspector.PrettyPrint(info)
+    assert.Contains(t, output, "Value: https://api.example.com")
+    assert.Contains(t, output, "  - apiKey:\n    Type: string\n    Kind: string\n    Exported: false\n    Required: false\n    Value: ****")
+    assert.NotContains(t, output, "sk-live-123")
+
+    // An empty result omits the Value line
+    plain := NewInspector().SetValueFormatter(func(field FieldInfo) string {
+        if field.IsExported {
+            return ""
+        }
+        return "hidden"
+    })
+    info, err = plain.InspectStruct(target)
+    require.NoError(t, err)
+    output = plain.PrettyPrint(info)
+    assert.Contains(t, output, "Value: hidden")
+    assert.NotContains(t, output, "api.example.com")
+
+    // Without a formatter unexported values stay unprinted even when they were read
+    info, err = NewInspector().WithUnsafeValues(true).InspectStruct(target)
+    require.NoError(t, err)
+    assert.NotContains(t, NewInspector().PrettyPrint(info), "sk-live-123")
+}
+
 func TestFieldInfoHandling(t *testing.T) {
     inspector := NewInspector()
 
@@ -144,4 +221,139 @@ func TestFieldInfoHandling(t *testing.T) {
 
     // Check map field
     assert.Equal(t, "map[string]interface {}", info.Fields[2].Type)
-}
\ No newline at end of file
+}
+
+func TestInspector_Warnings(t *testing.T) {
+    inspector := NewInspector()
+
+    type Conflicting struct {
+        Retries  int    `di:"retry-count" required:"true" default:"3"`
+        hidden   string `di:"hidden" required:"true"`
+        Optional string `di:"optional" default:"x"`
+        Clean    string `di:"clean" required:"true"`
+    }
+
+    info, err := inspector.InspectStruct(&Conflicting{})
+    require.NoError(t, err)
+
+    require.Len(t, info.Warnings, 2)
+    assert.Contains(t, info.Warnings[0], "Retries is required but also declares default \"3\"")
+    assert.Contains(t, info.Warnings[1], "hidden is required but unexported")
+
+    output := inspector.PrettyPrint(info)
+    assert.Contains(t, output, "Warnings:")
+    assert.Contains(t, output, "Retries is required but also declares default")
+
+    // Structs without conflicts report no warnings
+    clean, err := inspector.InspectStruct(TestStruct{})
+    require.NoError(t, err)
+    assert.Empty(t, clean.Warnings)
+    assert.NotContains(t, inspector.PrettyPrint(clean), "Warnings:")
+}
+
+type conventionService struct{}
+
+func (s *conventionService) Scope() container.Scope { return container.Prototype }
+func (s *conventionService) Profiles() []string     { return []string{"dev"} }
+
+type singletonService struct{}
+
+func (s singletonService) Scope() container.Scope { return container.Singleton }
+
+func TestInspector_ConventionMethods(t *testing.T) {
+    inspector := NewInspector()
+
+    // Pointer receivers are called on pointer and value targets alike
+    for _, target := range []interface{}{&conventionService{}, conventionService{}} {
+        info, err := inspector.InspectStruct(target)
+        require.NoError(t, err)
+        assert.Equal(t, container.Prototype, info.Scope)
+        assert.Equal(t, []string{"dev"}, info.ActiveProfiles)
+        assert.Contains(t, inspector.PrettyPrint(info), "  - dev")
+    }
+
+    info, err := inspector.InspectStruct(singletonService{})
+    require.NoError(t, err)
+    assert.Equal(t, container.Singleton, info.Scope)
+    assert.Empty(t, info.ActiveProfiles)
+
+    // Without an instance the declared method cannot be called
+    structType := reflect.TypeOf(conventionService{})
+    assert.Equal(t, container.Singleton, inspector.determineScope(reflect.Value{}, structType))
+    assert.Empty(t, inspector.getActiveProfiles(reflect.Value{}, structType))
+}
+
+func TestInspector_WithUnsafeValues(t *testing.T) {
+    target := TestStruct{PublicField: "public", privateField: "secret"}
+
+    info, err := NewInspector().InspectStruct(target)
+    require.NoError(t, err)
+    assert.Nil(t, info.Fields[1].Value, "unexported values stay hidden by default")
+
+    info, err = NewInspector().WithUnsafeValues(true).InspectStruct(&target)
+    require.NoError(t, err)
+    assert.Equal(t, "privateField", info.Fields[1].Name)
+    assert.False(t, info.Fields[1].IsExported)
+    assert.Equal(t, "secret", info.Fields[1].Value)
+
+    // Value targets are copied to an addressable instance, so they work too
+    info, err = NewInspector().WithUnsafeValues(true).InspectStruct(target)
+    require.NoError(t, err)
+    assert.Equal(t, "secret", info.Fields[1].Value)
+}
+
+func TestInspector_FieldKinds(t *testing.T) {
+    type kindStruct struct {
+        Events   chan int
+        Results  <-chan string
+        Validate func(int) error
+        Source   io.Reader
+    }
+
+    inspector := NewInspector()
+    info, err := inspector.InspectStruct(kindStruct{})
+    require.NoError(t, err)
+    require.Len(t, info.Fields, 4)
+
+    tests := []struct {
+        kind      reflect.Kind
+        chanDir   reflect.ChanDir
+        signature string
+    }{
+        {kind: reflect.Chan, chanDir: reflect.BothDir},
+        {kind: reflect.Chan, chanDir: reflect.RecvDir},
+        {kind: reflect.Func, signature: "func(int) error"},
+        {kind: reflect.Interface},
+    }
+    for i, tt := range tests {
+        field := info.Fields[i]
+        t.Run(field.Name, func(t *testing.T) {
+            assert.Equal(t, tt.kind, field.Kind)
+            assert.Equal(t, tt.chanDir, field.ChanDir)
+            assert.Equal(t, tt.signature, field.FuncSignature)
+        })
+    }
+    assert.Equal(t, "io.Reader", info.Fields[3].Type)
+
+    output := inspector.PrettyPrint(info)
+    assert.Contains(t, output, "Channel Direction: <-chan")
+    assert.Contains(t, output, "Signature: func(int) error")
+    assert.Contains(t, output, "Kind: interface")
+}
+
+type timingAroundAspect struct{}
+
+func (a *timingAroundAspect) Kind() aop.AspectKind          { return aop.Around }
+func (a *timingAroundAspect) PointCut() string              { return "*.Process*" }
+func (a *timingAroundAspect) Advice(jp *aop.JoinPoint) error { return nil }
+
+func TestInspector_AspectKindNames(t *testing.T) {
+    inspector := NewInspector()
+    info, err := inspector.InspectStruct(&timingAroundAspect{})
+    require.NoError(t, err)
+
+    require.True(t, info.AspectInfo.HasAspects)
+    assert.Equal(t, []string{"Around"}, info.AspectInfo.Advices)
+    assert.Equal(t, []string{"*.Process*"}, info.AspectInfo.PointCuts)
+    assert.Contains(t, inspector.PrettyPrint(info), "Advice Type: Around")
+}
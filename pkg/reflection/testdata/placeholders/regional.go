@@ -0,0 +1,9 @@
+package placeholders
+
+type Exporter interface {
+    Export(data []byte) error
+}
+
+type regionalExporter struct {
+    Primary Exporter `di:"exporter-${region}"`
+}
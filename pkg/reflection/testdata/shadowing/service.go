@@ -0,0 +1,12 @@
+package shadowing
+
+type Logger interface {
+    Log(message string)
+}
+
+// service and fmt are package-level names the generated code would otherwise use itself
+type service struct {
+    Logger Logger `di:"logger"`
+}
+
+func fmt() {}
@@ -0,0 +1,27 @@
+package wiring
+
+type PaymentProcessor interface {
+    ProcessPayment(amount float64) error
+}
+
+type InventoryService interface {
+    CheckStock(itemID string) (int, error)
+}
+
+type NotificationService interface {
+    NotifyUser(userID, message string) error
+}
+
+type orderServiceImpl struct {
+    PaymentProcessor PaymentProcessor    `di:"paymentService" required:"true"`
+    Inventory        InventoryService    `di:"inventoryService" required:"true"`
+    Notifications    NotificationService `di:"notificationService"`
+    Notifiers        []NotificationService `di:"all"`
+    Channels         map[string]NotificationService `di:"prefix:notify."`
+    audit            *string             `di:"auditLog"`
+    createdOrders    int
+}
+
+type untagged struct {
+    Name string
+}
@@ -0,0 +1,13 @@
+package wiring
+
+type Exporter interface {
+    Export(data []byte) error
+}
+
+type reportServiceImpl struct {
+    Exporter Exporter `di:"exporter" required:"true"`
+    Debug    Exporter `di:"debugExporter" profile:"dev, test"`
+    Cache    Exporter `di:"cache-${env}"`
+}
+
+func (r *reportServiceImpl) PostConstruct() error { return nil }
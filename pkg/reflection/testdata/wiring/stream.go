@@ -0,0 +1,20 @@
+package wiring
+
+import (
+    "io"
+    stdlog "log"
+)
+
+type Logger interface {
+    Log(message string)
+}
+
+type streamServiceImpl struct {
+    Source  io.Reader                `di:"source" required:"true"`
+    Logger  *Logger                  `di:"logger"`
+    Audit   *stdlog.Logger           `di:"requestLog"`
+    Sink    func() io.Writer         `di:"sink"`
+    Lookup  func() (Exporter, error) `di:"exporter" required:"true"`
+    Retries int                      `di:"retries"`
+}
+
@@ -0,0 +1,446 @@
+package reflection
+
+import (
+    "bytes"
+    "fmt"
+    "go/ast"
+    "go/format"
+    "go/importer"
+    "go/parser"
+    "go/token"
+    "go/types"
+    "os"
+    "path"
+    "path/filepath"
+    "reflect"
+    "sort"
+    "strings"
+    "unicode"
+)
+
+// containerImportPath is the import path generated wiring code uses for the container
+const containerImportPath = "di-extended/pkg/container"
+
+// wireKind selects how generated code assigns a resolved service to a field
+type wireKind int
+
+const (
+    wireAssign   wireKind = iota // Type assertion to the field type
+    wirePointer                  // Pointer to interface, also filled from a service implementing the interface
+    wireProvider                 // func() T or func() (T, error) field that resolves on every call
+)
+
+// wireField is a di-tagged field the generated code injects
+type wireField struct {
+    name         string
+    kind         wireKind
+    typeExpr     string // Field type as written in the generated file
+    elemExpr     string // Interface of a pointer field or service type of a provider field
+    returnsError bool   // Whether a provider field returns (T, error)
+    qualifier    string
+    required     bool
+    profiles     []string // From the profile tag; the field is only wired while one is active
+}
+
+// skippedField is a di-tagged field left to InjectStruct, named in a comment of the generated code
+type skippedField struct {
+    name   string
+    reason string
+}
+
+// wireStruct is a struct with di-tagged fields
+type wireStruct struct {
+    name    string
+    fields  []wireField
+    skipped []skippedField
+}
+
+// GenerateWireCode type-checks the Go package in directory pkgPath and generates reflection-free
+// wiring code for every package-level struct with di-tagged fields: one Wire<Struct>(c, target)
+// function per struct that resolves each qualifier and assigns it. Like InjectStruct, fields with
+// a profile tag are only wired while one of their profiles is active, pointers to interfaces are
+// filled from services implementing the interface, func() T and func() (T, error) fields become
+// providers that resolve on every call, and unexported fields, di:"all" slices and
+// di:"prefix:..." maps are skipped.
+// The generated code covers plain qualifier lookups only and is not a full replacement for
+// InjectStruct:
+//   - fields whose qualifier has ${...} placeholders and scalar fields are skipped, with a comment
+//     naming them, because placeholders and scalar conversions are only resolved at runtime
+//   - PostConstruct, lifecycle hooks and init methods of the target are not run
+//   - container options such as SetRequiredByDefault or AllowConvertibleInjection are ignored
+//
+// The package and its imports must type-check. The returned source belongs to the scanned
+// package, imports exactly the packages it uses and is gofmt-formatted.
+func GenerateWireCode(pkgPath string) (string, error) {
+    entries, err := os.ReadDir(pkgPath)
+    if err != nil {
+        return "", fmt.Errorf("failed to read package %s: %w", pkgPath, err)
+    }
+
+    fset := token.NewFileSet()
+    files := make([]*ast.File, 0, len(entries))
+    pkgName := ""
+    for _, entry := range entries {
+        name := entry.Name()
+        if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+            continue
+        }
+        file, err := parser.ParseFile(fset, filepath.Join(pkgPath, name), nil, 0)
+        if err != nil {
+            return "", fmt.Errorf("failed to parse %s: %w", name, err)
+        }
+        if pkgName != "" && file.Name.Name != pkgName {
+            return "", fmt.Errorf("multiple packages in %s: %s and %s", pkgPath, pkgName, file.Name.Name)
+        }
+        pkgName = file.Name.Name
+        files = append(files, file)
+    }
+    if len(files) == 0 {
+        return "", fmt.Errorf("no Go files found in %s", pkgPath)
+    }
+
+    // Field types are only known exactly after type-checking, e.g. whether an imported
+    // type is an interface or which package a qualified name refers to
+    info := &types.Info{Defs: make(map[*ast.Ident]types.Object)}
+    config := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+    pkg, err := config.Check(pkgName, fset, files, info)
+    if err != nil {
+        return "", fmt.Errorf("failed to type-check %s: %w", pkgPath, err)
+    }
+
+    generator := newWireGenerator(pkg)
+    structs := make([]wireStruct, 0)
+    for _, file := range files {
+        for _, decl := range file.Decls {
+            genDecl, ok := decl.(*ast.GenDecl)
+            if !ok || genDecl.Tok != token.TYPE {
+                continue
+            }
+            for _, spec := range genDecl.Specs {
+                typeSpec := spec.(*ast.TypeSpec)
+                if typeSpec.TypeParams != nil {
+                    continue
+                }
+                object := info.Defs[typeSpec.Name]
+                if object == nil {
+                    continue
+                }
+                structType, ok := object.Type().Underlying().(*types.Struct)
+                if !ok {
+                    continue
+                }
+                if wired := generator.wireStruct(typeSpec.Name.Name, structType); len(wired.fields) > 0 || len(wired.skipped) > 0 {
+                    structs = append(structs, wired)
+                }
+            }
+        }
+    }
+    sort.Slice(structs, func(i, j int) bool { return structs[i].name < structs[j].name })
+
+    for _, wired := range structs {
+        generator.writeWireFunc(wired)
+    }
+
+    var buf bytes.Buffer
+    fmt.Fprintf(&buf, "// Code generated by reflection.GenerateWireCode. DO NOT EDIT.\n\n")
+    fmt.Fprintf(&buf, "package %s\n\n", pkgName)
+    generator.writeImports(&buf)
+    buf.Write(generator.body.Bytes())
+
+    formatted, err := format.Source(buf.Bytes())
+    if err != nil {
+        return "", fmt.Errorf("failed to format generated code: %w", err)
+    }
+    return string(formatted), nil
+}
+
+// wireGenerator renders the wiring functions of one type-checked package and tracks the
+// identifiers they use, so imports and variables never clash with the package's own names
+type wireGenerator struct {
+    pkg     *types.Package
+    imports map[string]string // Import path -> name the generated file uses for it
+    taken   map[string]bool
+    body    bytes.Buffer
+
+    // Variable names in the generated functions
+    c, target, service, typed, ok, err string
+}
+
+func newWireGenerator(pkg *types.Package) *wireGenerator {
+    g := &wireGenerator{
+        pkg:     pkg,
+        imports: make(map[string]string),
+        taken:   make(map[string]bool),
+    }
+    g.c = g.reserve("c")
+    g.target = g.reserve("target")
+    g.service = g.reserve("service")
+    g.typed = g.reserve("typed")
+    g.ok = g.reserve("ok")
+    g.err = g.reserve("err")
+    return g
+}
+
+// reserve returns name, or name with a numeric suffix if the package or the generated
+// file already uses it, and marks the result as taken
+func (g *wireGenerator) reserve(name string) string {
+    candidate := name
+    for i := 2; g.taken[candidate] || g.pkg.Scope().Lookup(candidate) != nil; i++ {
+        candidate = fmt.Sprintf("%s%d", name, i)
+    }
+    g.taken[candidate] = true
+    return candidate
+}
+
+// importName returns the name the generated file uses for the package at importPath,
+// adding the import on first use
+func (g *wireGenerator) importName(importPath, name string) string {
+    if existing, ok := g.imports[importPath]; ok {
+        return existing
+    }
+    g.imports[importPath] = g.reserve(name)
+    return g.imports[importPath]
+}
+
+// typeString renders t as it is written in the generated file, importing the packages it names
+func (g *wireGenerator) typeString(t types.Type) string {
+    return types.TypeString(t, func(other *types.Package) string {
+        if other == g.pkg {
+            return ""
+        }
+        return g.importName(other.Path(), other.Name())
+    })
+}
+
+// fmtErrorf returns a call to fmt.Errorf with the given message, which may contain verbs
+func (g *wireGenerator) fmtErrorf(message string, args ...string) string {
+    call := fmt.Sprintf("%s.Errorf(%q", g.importName("fmt", "fmt"), message)
+    for _, arg := range args {
+        call += ", " + arg
+    }
+    return call + ")"
+}
+
+// writeImports writes the import block, standard library packages first
+func (g *wireGenerator) writeImports(buf *bytes.Buffer) {
+    paths := make([]string, 0, len(g.imports))
+    for importPath := range g.imports {
+        paths = append(paths, importPath)
+    }
+    sort.Slice(paths, func(i, j int) bool {
+        iStd, jStd := isStdImport(paths[i]), isStdImport(paths[j])
+        if iStd != jStd {
+            return iStd
+        }
+        return paths[i] < paths[j]
+    })
+
+    fmt.Fprintf(buf, "import (\n")
+    for i, importPath := range paths {
+        if i > 0 && isStdImport(paths[i-1]) && !isStdImport(importPath) {
+            fmt.Fprintf(buf, "\n")
+        }
+        if name := g.imports[importPath]; name != path.Base(importPath) {
+            fmt.Fprintf(buf, "%s %q\n", name, importPath)
+        } else {
+            fmt.Fprintf(buf, "%q\n", importPath)
+        }
+    }
+    fmt.Fprintf(buf, ")\n")
+}
+
+// isStdImport reports whether an import path belongs to the standard library
+func isStdImport(importPath string) bool {
+    first, _, _ := strings.Cut(importPath, "/")
+    return !strings.Contains(first, ".") && importPath != containerImportPath
+}
+
+// wireStruct collects the di-tagged fields of a struct in declaration order, classified the
+// way InjectStruct treats them
+func (g *wireGenerator) wireStruct(name string, structType *types.Struct) wireStruct {
+    wired := wireStruct{name: name}
+    for i := 0; i < structType.NumFields(); i++ {
+        field := structType.Field(i)
+        tag := reflect.StructTag(structType.Tag(i))
+        qualifier, tagged := tag.Lookup("di")
+        if !tagged || qualifier == "" || field.Embedded() || !field.Exported() {
+            continue
+        }
+        fieldType := field.Type()
+        if _, isSlice := fieldType.Underlying().(*types.Slice); isSlice && qualifier == "all" {
+            continue
+        }
+        if isStringKeyedMap(fieldType) && strings.HasPrefix(qualifier, "prefix:") {
+            continue
+        }
+        if strings.Contains(qualifier, "${") {
+            wired.skipped = append(wired.skipped, skippedField{
+                name:   field.Name(),
+                reason: fmt.Sprintf("has placeholder qualifier %q", qualifier),
+            })
+            continue
+        }
+        if _, isBasic := fieldType.Underlying().(*types.Basic); isBasic {
+            wired.skipped = append(wired.skipped, skippedField{
+                name:   field.Name(),
+                reason: "is a scalar field converted at runtime",
+            })
+            continue
+        }
+
+        wiredField := wireField{
+            name:      field.Name(),
+            kind:      wireAssign,
+            typeExpr:  g.typeString(fieldType),
+            qualifier: qualifier,
+            required:  tag.Get("required") == "true",
+            profiles:  profileList(tag.Get("profile")),
+        }
+        if serviceType, returnsError, ok := providerResult(fieldType); ok {
+            wiredField.kind = wireProvider
+            wiredField.elemExpr = g.typeString(serviceType)
+            wiredField.returnsError = returnsError
+        } else if pointer, ok := fieldType.Underlying().(*types.Pointer); ok && types.IsInterface(pointer.Elem()) {
+            wiredField.kind = wirePointer
+            wiredField.elemExpr = g.typeString(pointer.Elem())
+        }
+        wired.fields = append(wired.fields, wiredField)
+    }
+    return wired
+}
+
+// isStringKeyedMap reports whether t is a map with string keys, the only kind di:"prefix:..." fills
+func isStringKeyedMap(t types.Type) bool {
+    mapType, ok := t.Underlying().(*types.Map)
+    if !ok {
+        return false
+    }
+    key, ok := mapType.Key().Underlying().(*types.Basic)
+    return ok && key.Kind() == types.String
+}
+
+// providerResult returns the service type of a func() T or func() (T, error) field type
+func providerResult(t types.Type) (serviceType types.Type, returnsError bool, ok bool) {
+    signature, isFunc := t.Underlying().(*types.Signature)
+    if !isFunc || signature.Params().Len() != 0 || signature.Variadic() {
+        return nil, false, false
+    }
+    results := signature.Results()
+    switch {
+    case results.Len() == 1:
+        return results.At(0).Type(), false, true
+    case results.Len() == 2 && types.Identical(results.At(1).Type(), types.Universe.Lookup("error").Type()):
+        return results.At(0).Type(), true, true
+    default:
+        return nil, false, false
+    }
+}
+
+// profileList splits a comma-separated profile tag, dropping blanks
+func profileList(value string) []string {
+    profiles := make([]string, 0)
+    for _, profile := range strings.Split(value, ",") {
+        if profile = strings.TrimSpace(profile); profile != "" {
+            profiles = append(profiles, profile)
+        }
+    }
+    return profiles
+}
+
+// writeWireFunc writes the wiring function for one struct
+func (g *wireGenerator) writeWireFunc(wired wireStruct) {
+    buf := &g.body
+    funcName := "Wire" + exportedName(wired.name)
+    fmt.Fprintf(buf, "\n// %s injects the di-tagged fields of target without reflection\n", funcName)
+    fmt.Fprintf(buf, "func %s(%s *%s.Container, %s *%s) error {\n",
+        funcName, g.c, g.importName(containerImportPath, "container"), g.target, wired.name)
+    for _, field := range wired.skipped {
+        fmt.Fprintf(buf, "// Not wired: %s %s, use InjectStruct\n", field.name, field.reason)
+    }
+    for _, field := range wired.fields {
+        if len(field.profiles) > 0 {
+            conditions := make([]string, len(field.profiles))
+            for i, profile := range field.profiles {
+                conditions[i] = fmt.Sprintf("%s.IsProfileActive(%q)", g.c, profile)
+            }
+            fmt.Fprintf(buf, "if %s {\n", strings.Join(conditions, " || "))
+        }
+        if field.kind == wireProvider {
+            g.writeProviderField(field)
+        } else {
+            g.writeResolvedField(field)
+        }
+        if len(field.profiles) > 0 {
+            fmt.Fprintf(buf, "}\n")
+        }
+    }
+    fmt.Fprintf(buf, "return nil\n}\n")
+}
+
+// writeResolvedField writes the code resolving a field's service once and assigning it
+func (g *wireGenerator) writeResolvedField(field wireField) {
+    buf := &g.body
+    fmt.Fprintf(buf, "if %s, %s := %s.Resolve(%q); %s == nil {\n", g.service, g.err, g.c, field.qualifier, g.err)
+    mismatch := g.fmtErrorf("service type %T is not assignable to field type "+field.typeExpr, g.service)
+    if field.kind == wirePointer {
+        fmt.Fprintf(buf, "switch %s := %s.(type) {\n", g.typed, g.service)
+        fmt.Fprintf(buf, "case %s:\n%s.%s = %s\n", field.typeExpr, g.target, field.name, g.typed)
+        fmt.Fprintf(buf, "case %s:\n%s.%s = &%s\n", field.elemExpr, g.target, field.name, g.typed)
+        fmt.Fprintf(buf, "default:\nreturn %s\n}\n", mismatch)
+    } else {
+        fmt.Fprintf(buf, "%s, %s := %s.(%s)\n", g.typed, g.ok, g.service, field.typeExpr)
+        fmt.Fprintf(buf, "if !%s {\nreturn %s\n}\n", g.ok, mismatch)
+        fmt.Fprintf(buf, "%s.%s = %s\n", g.target, field.name, g.typed)
+    }
+    if field.required {
+        fmt.Fprintf(buf, "} else {\nreturn %s\n",
+            g.fmtErrorf("required service not found for field "+field.name+": %w", g.err))
+    }
+    fmt.Fprintf(buf, "}\n")
+}
+
+// writeProviderField writes the code assigning a provider that resolves the field's service on
+// every call. Like InjectStruct, only the registration is checked up front, and a func() T
+// provider panics on failure since it has no other way to report it.
+func (g *wireGenerator) writeProviderField(field wireField) {
+    buf := &g.body
+    fmt.Fprintf(buf, "if %s.Has(%q) {\n", g.c, field.qualifier)
+    fmt.Fprintf(buf, "%s.%s = func() %s {\n", g.target, field.name, resultList(field))
+    fmt.Fprintf(buf, "%s, %s := %s.Resolve(%q)\n", g.service, g.err, g.c, field.qualifier)
+    fmt.Fprintf(buf, "if %s == nil {\n", g.err)
+    fmt.Fprintf(buf, "if %s, %s := %s.(%s); %s {\n", g.typed, g.ok, g.service, field.elemExpr, g.ok)
+    if field.returnsError {
+        fmt.Fprintf(buf, "return %s, nil\n}\n", g.typed)
+    } else {
+        fmt.Fprintf(buf, "return %s\n}\n", g.typed)
+    }
+    fmt.Fprintf(buf, "%s = %s\n}\n", g.err,
+        g.fmtErrorf("service %s of type %T is not assignable to "+field.elemExpr, fmt.Sprintf("%q", field.qualifier), g.service))
+    if field.returnsError {
+        fmt.Fprintf(buf, "return *new(%s), %s\n", field.elemExpr, g.err)
+    } else {
+        fmt.Fprintf(buf, "panic(%s)\n", g.fmtErrorf("provider for %s: %w", fmt.Sprintf("%q", field.qualifier), g.err))
+    }
+    fmt.Fprintf(buf, "}\n")
+    if field.required {
+        fmt.Fprintf(buf, "} else {\nreturn %s\n", g.fmtErrorf(
+            "required service not found for field "+field.name+": no service found for qualifier: %s",
+            fmt.Sprintf("%q", field.qualifier)))
+    }
+    fmt.Fprintf(buf, "}\n")
+}
+
+// resultList renders the results of a provider field's function type
+func resultList(field wireField) string {
+    if field.returnsError {
+        return "(" + field.elemExpr + ", error)"
+    }
+    return field.elemExpr
+}
+
+// exportedName upper-cases the first letter of a type name for use in a function name
+func exportedName(name string) string {
+    runes := []rune(name)
+    runes[0] = unicode.ToUpper(runes[0])
+    return string(runes)
+}
@@ -0,0 +1,133 @@
+package reflection
+
+import (
+    "go/ast"
+    "go/importer"
+    "go/parser"
+    "go/token"
+    "go/types"
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+)
+
+func TestGenerateWireCode(t *testing.T) {
+    code, err := GenerateWireCode("testdata/wiring")
+    require.NoError(t, err)
+    typeCheckGenerated(t, "testdata/wiring", code)
+
+    assert.Contains(t, code, "func WireOrderServiceImpl(c *container.Container, target *orderServiceImpl) error {")
+    assert.Contains(t, code, `c.Resolve("paymentService")`)
+    assert.Contains(t, code, "typed, ok := service.(PaymentProcessor)")
+    assert.Contains(t, code, "target.PaymentProcessor = typed")
+    assert.Contains(t, code, "target.Inventory = typed")
+    assert.Contains(t, code, "target.Notifications = typed")
+    assert.Contains(t, code, `required service not found for field Inventory`)
+    assert.NotContains(t, code, "required service not found for field Notifications")
+
+    // Unexported fields, di:"all" slices, di:"prefix:..." maps and untagged structs are not wired
+    assert.NotContains(t, code, "auditLog")
+    assert.NotContains(t, code, "Notifiers")
+    assert.NotContains(t, code, "Channels")
+    assert.NotContains(t, code, "Untagged")
+}
+
+func TestGenerateWireCodeScope(t *testing.T) {
+    code, err := GenerateWireCode("testdata/wiring")
+    require.NoError(t, err)
+
+    // Profile tags are checked at runtime like InjectStruct does
+    assert.Contains(t, code, `if c.IsProfileActive("dev") || c.IsProfileActive("test") {`)
+    assert.Contains(t, code, "target.Debug = typed")
+
+    // Placeholder qualifiers and PostConstruct are left to InjectStruct
+    assert.Contains(t, code, `// Not wired: Cache has placeholder qualifier "cache-${env}", use InjectStruct`)
+    assert.NotContains(t, code, "target.Cache")
+    assert.NotContains(t, code, "PostConstruct")
+}
+
+func TestGenerateWireCodeFieldKinds(t *testing.T) {
+    code, err := GenerateWireCode("testdata/wiring")
+    require.NoError(t, err)
+
+    // Imported field types bring their imports along
+    assert.Contains(t, code, `"io"`)
+    assert.Contains(t, code, `"log"`)
+    assert.Contains(t, code, "service.(io.Reader)")
+    assert.Contains(t, code, "service.(*log.Logger)")
+
+    // A pointer to an interface also takes a service implementing the interface
+    assert.Contains(t, code, "case Logger:\n\t\t\ttarget.Logger = &typed")
+
+    // Providers resolve on every call and only check the registration up front
+    assert.Contains(t, code, `if c.Has("sink") {`)
+    assert.Contains(t, code, "target.Sink = func() io.Writer {")
+    assert.Contains(t, code, "target.Lookup = func() (Exporter, error) {")
+    assert.Contains(t, code, `required service not found for field Lookup`)
+
+    // Scalars are converted at runtime by InjectStruct
+    assert.Contains(t, code, "// Not wired: Retries is a scalar field converted at runtime, use InjectStruct")
+    assert.NotContains(t, code, "target.Retries")
+}
+
+func TestGenerateWireCodeNameClashes(t *testing.T) {
+    code, err := GenerateWireCode("testdata/shadowing")
+    require.NoError(t, err)
+    typeCheckGenerated(t, "testdata/shadowing", code)
+
+    // The package declares service and fmt, so the generated code renames its own
+    assert.Contains(t, code, `fmt2 "fmt"`)
+    assert.Contains(t, code, `if service2, err := c.Resolve("logger"); err == nil {`)
+    assert.Contains(t, code, "func WireService(c *container.Container, target *service) error {")
+}
+
+func TestGenerateWireCodePlaceholdersOnly(t *testing.T) {
+    code, err := GenerateWireCode("testdata/placeholders")
+    require.NoError(t, err)
+    typeCheckGenerated(t, "testdata/placeholders", code)
+
+    // Nothing is resolved, so fmt is not imported
+    assert.NotContains(t, code, `"fmt"`)
+    assert.Contains(t, code, `// Not wired: Primary has placeholder qualifier "exporter-${region}", use InjectStruct`)
+}
+
+// The source importer caches the packages it type-checks, the container package among them,
+// so all checks share one
+var (
+    generatedFset     = token.NewFileSet()
+    generatedImporter = importer.ForCompiler(generatedFset, "source", nil)
+)
+
+// typeCheckGenerated type-checks generated code together with the package it was generated for
+func typeCheckGenerated(t *testing.T, dir, code string) {
+    t.Helper()
+
+    fset := generatedFset
+    entries, err := os.ReadDir(dir)
+    require.NoError(t, err)
+    files := make([]*ast.File, 0, len(entries)+1)
+    for _, entry := range entries {
+        if !strings.HasSuffix(entry.Name(), ".go") {
+            continue
+        }
+        file, err := parser.ParseFile(fset, filepath.Join(dir, entry.Name()), nil, 0)
+        require.NoError(t, err)
+        files = append(files, file)
+    }
+    generated, err := parser.ParseFile(fset, filepath.Join(dir, "wire_gen.go"), code, 0)
+    require.NoError(t, err)
+    files = append(files, generated)
+
+    config := types.Config{Importer: generatedImporter}
+    _, err = config.Check(generated.Name.Name, fset, files, nil)
+    require.NoError(t, err, code)
+}
+
+func TestGenerateWireCodeErrors(t *testing.T) {
+    _, err := GenerateWireCode("testdata/missing")
+    assert.Error(t, err)
+}